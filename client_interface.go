@@ -3,6 +3,8 @@
 package clientnative
 
 import (
+	"context"
+
 	parser "github.com/haproxytech/config-parser/v3"
 
 	"github.com/haproxytech/client-native/v2/configuration"
@@ -20,8 +22,9 @@ type IConfigurationClient interface {
 	// DeleteACL deletes a ACL line in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteACL(id int64, parentType string, parentName string, transactionID string, version int64) error
-	// CreateACL creates a ACL line in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateACL creates a ACL line in configuration at data.Index, lines at that position
+	// and after it are moved down, a nil index appends the line. One of version or
+	// transactionID is mandatory. Returns error on fail, nil on success.
 	CreateACL(parentType string, parentName string, data *models.ACL, transactionID string, version int64) error
 	// EditACL edits a ACL line in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
@@ -50,8 +53,9 @@ type IConfigurationClient interface {
 	// DeleteBackendSwitchingRule deletes a backend switching rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteBackendSwitchingRule(id int64, frontend string, transactionID string, version int64) error
-	// CreateBackendSwitchingRule creates a backend switching rule in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateBackendSwitchingRule creates a backend switching rule in configuration at data.Index, rules at
+	// that position and after it are moved down, a nil index appends the rule. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateBackendSwitchingRule(frontend string, data *models.BackendSwitchingRule, transactionID string, version int64) error
 	// EditBackendSwitchingRule edits a backend switching rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
@@ -59,18 +63,117 @@ type IConfigurationClient interface {
 	// GetBinds returns configuration version and an array of
 	// configured binds in the specified frontend. Returns error on fail.
 	GetBinds(frontend string, transactionID string) (int64, models.Binds, error)
+	// GetBindsCtx is GetBinds that returns ctx.Err() as soon as ctx is done
+	GetBindsCtx(ctx context.Context, frontend string, transactionID string) (int64, models.Binds, error)
 	// GetBind returns configuration version and a requested bind
 	// in the specified frontend. Returns error on fail or if bind does not exist.
 	GetBind(name string, frontend string, transactionID string) (int64, *models.Bind, error)
+	// GetBindCtx is GetBind that returns ctx.Err() as soon as ctx is done
+	GetBindCtx(ctx context.Context, name string, frontend string, transactionID string) (int64, *models.Bind, error)
+	// GetAllBinds returns configuration version and the binds of every frontend,
+	// keyed by frontend name. Returns error on fail.
+	GetAllBinds(transactionID string) (int64, map[string]models.Binds, error)
+	// WalkBinds calls fn with each bind of every frontend, frontends sorted by name
+	// and binds in configuration order. Binds are parsed one at a time, so unlike
+	// GetAllBinds the memory used does not grow with the number of binds. The walk
+	// stops at the first error returned by fn, or with ctx.Err() as soon as ctx is
+	// done, and that error is returned. Returns configuration version and error on
+	// fail, nil on success.
+	WalkBinds(ctx context.Context, transactionID string, fn func(frontend string, bind *models.Bind) error) (int64, error)
+	// GetBindByAddress returns configuration version and a bind listening on the
+	// given address and port in the specified frontend. Port should be nil for binds
+	// without port, such as UNIX sockets, and the first port for port range binds.
+	// Returns error on fail or if bind does not exist.
+	GetBindByAddress(address string, port *int64, frontend string, transactionID string) (int64, *models.Bind, error)
 	// DeleteBind deletes a bind in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteBind(name string, frontend string, transactionID string, version int64) error
+	// DeleteBindCtx is DeleteBind that returns ctx.Err() if ctx is done before the
+	// configuration is changed or, without transactionID, committed
+	DeleteBindCtx(ctx context.Context, name string, frontend string, transactionID string, version int64) error
+	// DeleteBindsWhere deletes all binds of the frontend for which match returns true
+	// using a single transaction save. One of version or transactionID is mandatory.
+	// Returns the number of deleted binds and error on fail, nil on success.
+	DeleteBindsWhere(frontend string, match func(*models.Bind) bool, transactionID string, version int64) (int, error)
 	// CreateBind creates a bind in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	CreateBind(frontend string, data *models.Bind, transactionID string, version int64) error
+	// CreateBindCtx is CreateBind that returns ctx.Err() if ctx is done before the
+	// configuration is changed or, without transactionID, committed
+	CreateBindCtx(ctx context.Context, frontend string, data *models.Bind, transactionID string, version int64) error
+	// CreateBindAt creates a bind in configuration at the given position of the
+	// frontend bind lines, negative index appends the bind. One of version or
+	// transactionID is mandatory. Returns error on fail, nil on success.
+	CreateBindAt(frontend string, index int64, data *models.Bind, transactionID string, version int64) error
+	// CreateBindAtCtx is CreateBindAt that returns ctx.Err() if ctx is done before the
+	// configuration is changed or, without transactionID, committed
+	CreateBindAtCtx(ctx context.Context, frontend string, index int64, data *models.Bind, transactionID string, version int64) error
+	// CreateBindEnsureFrontend creates a bind in configuration like CreateBind, but
+	// first creates the frontend, with only its name, if it does not exist. Both are
+	// created in the same transaction and if the bind can't be inserted the frontend
+	// is not created either. One of version or transactionID is mandatory. Returns
+	// error on fail, nil on success.
+	CreateBindEnsureFrontend(frontend string, data *models.Bind, transactionID string, version int64) error
+	// CreateBinds creates multiple binds in configuration using a single transaction save.
+	// One of version or transactionID is mandatory. All binds are checked before any is
+	// inserted, if one of them fails none is created. Returns error on fail, nil on success.
+	CreateBinds(frontend string, data models.Binds, transactionID string, version int64) error
+	// ReplaceBinds replaces all binds in the frontend with the given ones, in the
+	// given order. Binds that are not changed are kept as they are on disk, the rest
+	// is created, edited or deleted in a single transaction save. One of version or
+	// transactionID is mandatory. Returns error on fail, nil on success.
+	ReplaceBinds(frontend string, data models.Binds, transactionID string, version int64) error
 	// EditBind edits a bind in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	EditBind(name string, frontend string, data *models.Bind, transactionID string, version int64) error
+	// EditBindCtx is EditBind that returns ctx.Err() if ctx is done before the
+	// configuration is changed or, without transactionID, committed
+	EditBindCtx(ctx context.Context, name string, frontend string, data *models.Bind, transactionID string, version int64) error
+	// RenameBind changes the name of a bind in configuration. Only the name option of
+	// the bind line is changed, the bind keeps its position and all its other options.
+	// One of version or transactionID is mandatory. Returns error on fail or if the
+	// frontend already has a bind named newName, nil on success.
+	RenameBind(oldName, newName, frontend string, transactionID string, version int64) error
+	// CloneBind copies a bind to another frontend, renamed to newName unless it is
+	// empty. One of version or transactionID is mandatory. Returns error on fail or
+	// if the destination frontend already has a bind with that name, nil on success.
+	CloneBind(name, srcFrontend, dstFrontend, newName string, transactionID string, version int64) error
+	// GetCaches returns configuration version and an array of
+	// configured caches. Returns error on fail.
+	GetCaches(transactionID string) (int64, models.Caches, error)
+	// GetCache returns configuration version and a requested cache.
+	// Returns error on fail or if cache does not exist.
+	GetCache(name string, transactionID string) (int64, *models.Cache, error)
+	// DeleteCache deletes a cache in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	DeleteCache(name string, transactionID string, version int64) error
+	// EditCache edits a cache in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	EditCache(name string, data *models.Cache, transactionID string, version int64) error
+	// CreateCache creates a cache in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateCache(data *models.Cache, transactionID string, version int64) error
+	// GetCaptures returns configuration version and an array of configured
+	// captures in the specified frontend, in the order they are declared. Request
+	// and response captures are numbered separately. Returns error on fail.
+	GetCaptures(frontend string, transactionID string) (int64, models.Captures, error)
+	// GetCapture returns configuration version and the capture in slot id of the
+	// captures of captureType in the specified frontend. Returns error on fail or if
+	// capture does not exist.
+	GetCapture(id int64, captureType string, frontend string, transactionID string) (int64, *models.Capture, error)
+	// DeleteCapture deletes the capture in slot id of the captures of captureType,
+	// captures of that type after it move up one slot. One of version or
+	// transactionID is mandatory. Returns error on fail, nil on success.
+	DeleteCapture(id int64, captureType string, frontend string, transactionID string, version int64) error
+	// CreateCapture creates a capture in configuration at data.Index among the
+	// captures of data.Type, captures of that type at that position and after it are
+	// moved down one slot, a nil index appends the capture. One of version or
+	// transactionID is mandatory. Returns error on fail, nil on success.
+	CreateCapture(frontend string, data *models.Capture, transactionID string, version int64) error
+	// EditCapture edits the capture in slot id of the captures of data.Type, its
+	// slot is kept. One of version or transactionID is mandatory. Returns error on
+	// fail, nil on success.
+	EditCapture(id int64, frontend string, data *models.Capture, transactionID string, version int64) error
 	// Init initializes a Client
 	Init(options configuration.ClientParams) error
 	// HasParser checks whether transaction exists in parser
@@ -87,13 +190,29 @@ type IConfigurationClient interface {
 	CommitParser(transactionID string) error
 	// InitTransactionParsers checks transactions and initializes parsers map with transactions in_progress
 	InitTransactionParsers() error
+	// ValidateTransaction checks a transaction without committing it, against the
+	// schema when UseValidation is set and with HAProxy when ValidateConfigurationFile
+	// is set. Returns nil when the transaction would pass these checks on commit.
+	ValidateTransaction(transactionID string) error
+	// CheckTransaction runs the HAProxy configuration check, or ValidateCmd if set, on
+	// the transaction without committing it. The check is stopped after CheckTimeout.
+	// Returns a validation error with one sub error per problem found on fail.
+	CheckTransaction(transactionID string) error
 	// GetVersion returns configuration file version
 	GetVersion(transactionID string) (int64, error)
+	// Snapshot returns a read-only view of the committed configuration. The same
+	// snapshot is returned until the next commit.
+	Snapshot() (*configuration.Snapshot, error)
 	IncrementVersion() error
 	IncrementTransactionVersion(transactionID string) error
 	LoadData(filename string) error
 	Save(transactionFile, transactionID string) error
 	GetFailedParserTransactionVersion(transactionID string) (int64, error)
+	// RegisterValidator adds a validator for objects given to the Create and Edit
+	// methods. Validators run only when UseValidation is set, after the model schema
+	// validation and before the semantic checks, in the order they were registered.
+	// Their errors are returned as a ConfError with the ErrValidationError code.
+	RegisterValidator(validator func(interface{}) error)
 	// GetDefaultsConfiguration returns configuration version and a
 	// struct representing Defaults configuration
 	GetDefaultsConfiguration(transactionID string) (int64, *models.Defaults, error)
@@ -109,8 +228,9 @@ type IConfigurationClient interface {
 	// DeleteFilter deletes a filter in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteFilter(id int64, parentType string, parentName string, transactionID string, version int64) error
-	// CreateFilter creates a filter in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateFilter creates a filter in configuration at data.Index, filters at that
+	// position and after it are moved down, a nil index appends the filter. One of
+	// version or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateFilter(parentType string, parentName string, data *models.Filter, transactionID string, version int64) error
 	// EditFilter edits a filter in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
@@ -136,6 +256,36 @@ type IConfigurationClient interface {
 	// PushGlobalConfiguration pushes a Global config struct to global
 	// config file
 	PushGlobalConfiguration(data *models.Global, transactionID string, version int64) error
+	// GetGroups returns configuration version and an array of
+	// configured groups in the specified userlist. Returns error on fail.
+	GetGroups(userlist string, transactionID string) (int64, models.Groups, error)
+	// GetGroup returns configuration version and a requested group
+	// in the specified userlist. Returns error on fail or if group does not exist.
+	GetGroup(name string, userlist string, transactionID string) (int64, *models.Group, error)
+	// DeleteGroup deletes a group in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	DeleteGroup(name string, userlist string, transactionID string, version int64) error
+	// CreateGroup creates a group in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateGroup(userlist string, data *models.Group, transactionID string, version int64) error
+	// EditGroup edits a group in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	EditGroup(name string, userlist string, data *models.Group, transactionID string, version int64) error
+	// GetHTTPErrorsSections returns configuration version and an array of
+	// configured http-errors sections. Returns error on fail.
+	GetHTTPErrorsSections(transactionID string) (int64, models.HTTPErrorsSections, error)
+	// GetHTTPErrorsSection returns configuration version and a requested http-errors section.
+	// Returns error on fail or if http-errors section does not exist.
+	GetHTTPErrorsSection(name string, transactionID string) (int64, *models.HTTPErrorsSection, error)
+	// DeleteHTTPErrorsSection deletes an http-errors section in configuration. One of version or
+	// transactionID is mandatory. Returns error on fail, nil on success.
+	DeleteHTTPErrorsSection(name string, transactionID string, version int64) error
+	// CreateHTTPErrorsSection creates an http-errors section in configuration. One of version or
+	// transactionID is mandatory. Returns error on fail, nil on success.
+	CreateHTTPErrorsSection(data *models.HTTPErrorsSection, transactionID string, version int64) error
+	// EditHTTPErrorsSection replaces the error files of an http-errors section in configuration.
+	// One of version or transactionID is mandatory. Returns error on fail, nil on success.
+	EditHTTPErrorsSection(name string, data *models.HTTPErrorsSection, transactionID string, version int64) error
 	// GetHTTPRequestRules returns configuration version and an array of
 	// configured http request rules in the specified parent. Returns error on fail.
 	GetHTTPRequestRules(parentType, parentName string, transactionID string) (int64, models.HTTPRequestRules, error)
@@ -145,8 +295,9 @@ type IConfigurationClient interface {
 	// DeleteHTTPRequestRule deletes a http request rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteHTTPRequestRule(id int64, parentType string, parentName string, transactionID string, version int64) error
-	// CreateHTTPRequestRule creates a http request rule in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateHTTPRequestRule creates a http request rule in configuration at data.Index, rules at
+	// that position and after it are moved down, a nil index appends the rule. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateHTTPRequestRule(parentType string, parentName string, data *models.HTTPRequestRule, transactionID string, version int64) error
 	// EditHTTPRequestRule edits a http request rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
@@ -160,14 +311,16 @@ type IConfigurationClient interface {
 	// DeleteHTTPResponseRule deletes a http response rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteHTTPResponseRule(id int64, parentType string, parentName string, transactionID string, version int64) error
-	// CreateHTTPResponseRule creates a http response rule in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateHTTPResponseRule creates a http response rule in configuration at data.Index, rules at
+	// that position and after it are moved down, a nil index appends the rule. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateHTTPResponseRule(parentType string, parentName string, data *models.HTTPResponseRule, transactionID string, version int64) error
 	// EditHTTPResponseRule edits a http response rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	EditHTTPResponseRule(id int64, parentType string, parentName string, data *models.HTTPResponseRule, transactionID string, version int64) error
 	// GetLogTargets returns configuration version and an array of
-	// configured log targets in the specified parent. Returns error on fail.
+	// configured log targets in the specified parent, parentType is one of
+	// global, frontend or backend. Returns error on fail.
 	GetLogTargets(parentType, parentName string, transactionID string) (int64, models.LogTargets, error)
 	// GetLogTarget returns configuration version and a requested log target
 	// in the specified parent. Returns error on fail or if log target does not exist.
@@ -175,12 +328,43 @@ type IConfigurationClient interface {
 	// DeleteLogTarget deletes a log target in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteLogTarget(id int64, parentType string, parentName string, transactionID string, version int64) error
-	// CreateLogTarget creates a log target in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateLogTarget creates a log target in configuration at data.Index, log targets at that
+	// position and after it are moved down, a nil index appends the log target. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateLogTarget(parentType string, parentName string, data *models.LogTarget, transactionID string, version int64) error
 	// EditLogTarget edits a log target in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	EditLogTarget(id int64, parentType string, parentName string, data *models.LogTarget, transactionID string, version int64) error
+	// GetMailerEntries returns configuration version and an array of
+	// configured mailer entries in the specified mailers section. Returns error on fail.
+	GetMailerEntries(mailersSection string, transactionID string) (int64, models.MailerEntries, error)
+	// GetMailerEntry returns configuration version and a requested mailer entry
+	// in the specified mailers section. Returns error on fail or if mailer entry does not exist.
+	GetMailerEntry(name string, mailersSection string, transactionID string) (int64, *models.MailerEntry, error)
+	// DeleteMailerEntry deletes a mailer entry in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	DeleteMailerEntry(name string, mailersSection string, transactionID string, version int64) error
+	// CreateMailerEntry creates a mailer entry in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateMailerEntry(mailersSection string, data *models.MailerEntry, transactionID string, version int64) error
+	// EditMailerEntry edits a mailer entry in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	EditMailerEntry(name string, mailersSection string, data *models.MailerEntry, transactionID string, version int64) error
+	// GetMailersSections returns configuration version and an array of
+	// configured mailers sections. Returns error on fail.
+	GetMailersSections(transactionID string) (int64, models.MailersSections, error)
+	// GetMailersSection returns configuration version and a requested mailers section.
+	// Returns error on fail or if mailers section does not exist.
+	GetMailersSection(name string, transactionID string) (int64, *models.MailersSection, error)
+	// DeleteMailersSection deletes a mailers section in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	DeleteMailersSection(name string, transactionID string, version int64) error
+	// EditMailersSection edits a mailers section in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	EditMailersSection(name string, data *models.MailersSection, transactionID string, version int64) error
+	// CreateMailersSection creates a mailers section in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateMailersSection(data *models.MailersSection, transactionID string, version int64) error
 	// GetNameservers returns configuration version and an array of
 	// configured namservers in the specified resolvers section. Returns error on fail.
 	GetNameservers(resolverSection string, transactionID string) (int64, models.Nameservers, error)
@@ -226,9 +410,37 @@ type IConfigurationClient interface {
 	// GetRawConfiguration returns configuration version and a
 	// string containing raw config file
 	GetRawConfiguration(transactionID string, version int64) (int64, string, error)
+	// CanonicalizeConfiguration rewrites the configuration in its canonical form,
+	// so that configurations generated from models and hand-written ones diff
+	// cleanly. One of version or transactionID is mandatory. Returns error on fail,
+	// nil on success.
+	//
+	// The canonical form is the one written by every change made with the client:
+	// global and defaults come first, followed by the userlist, peers, mailers,
+	// resolvers, cache, ring, http-errors, frontend, backend and program sections,
+	// each kind sorted by name. Within a section keywords follow the config-parser
+	// order, lines of the same keyword (binds, servers, rules) keep their relative
+	// order, and every line is indented with two spaces. Canonicalizing an already
+	// canonical configuration does not change it.
+	CanonicalizeConfiguration(transactionID string, version int64) error
 	// PostRawConfiguration pushes given string to the config file if the version
 	// matches
 	PostRawConfiguration(config *string, version int64, skipVersionCheck bool, onlyValidate ...bool) error
+	// GetPrograms returns configuration version and an array of
+	// configured programs. Returns error on fail.
+	GetPrograms(transactionID string) (int64, models.Programs, error)
+	// GetProgram returns configuration version and a requested program.
+	// Returns error on fail or if program does not exist.
+	GetProgram(name string, transactionID string) (int64, *models.Program, error)
+	// DeleteProgram deletes a program in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	DeleteProgram(name string, transactionID string, version int64) error
+	// EditProgram edits a program in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	EditProgram(name string, data *models.Program, transactionID string, version int64) error
+	// CreateProgram creates a program in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateProgram(data *models.Program, transactionID string, version int64) error
 	// GetResolvers returns configuration version and an array of
 	// configured resolvers. Returns error on fail.
 	GetResolvers(transactionID string) (int64, models.Resolvers, error)
@@ -244,9 +456,31 @@ type IConfigurationClient interface {
 	// CreateResolver creates a resolver in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	CreateResolver(data *models.Resolver, transactionID string, version int64) error
+	// GetRings returns configuration version and an array of
+	// configured rings. Returns error on fail.
+	GetRings(transactionID string) (int64, models.Rings, error)
+	// GetRing returns configuration version and a requested ring.
+	// Returns error on fail or if ring does not exist.
+	GetRing(name string, transactionID string) (int64, *models.Ring, error)
+	// DeleteRing deletes a ring in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	DeleteRing(name string, transactionID string, version int64) error
+	// EditRing edits a ring in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	EditRing(name string, data *models.Ring, transactionID string, version int64) error
+	// CreateRing creates a ring in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateRing(data *models.Ring, transactionID string, version int64) error
 	// GetServers returns configuration version and an array of
 	// configured servers in the specified backend. Returns error on fail.
 	GetServers(backend string, transactionID string) (int64, models.Servers, error)
+	// WalkServers calls fn with each server of every backend, backends sorted by
+	// name and servers in configuration order. Servers are parsed one at a time, so
+	// the memory used does not grow with the number of servers. The walk stops at
+	// the first error returned by fn, or with ctx.Err() as soon as ctx is done, and
+	// that error is returned. Returns configuration version and error on fail, nil
+	// on success.
+	WalkServers(ctx context.Context, transactionID string, fn func(backend string, server *models.Server) error) (int64, error)
 	// GetServer returns configuration version and a requested server
 	// in the specified backend. Returns error on fail or if server does not exist.
 	GetServer(name string, backend string, transactionID string) (int64, *models.Server, error)
@@ -259,6 +493,15 @@ type IConfigurationClient interface {
 	// EditServer edits a server in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	EditServer(name string, backend string, data *models.Server, transactionID string, version int64) error
+	// GetServersByBackend returns configuration version, the servers in the
+	// specified backend and their indexes in it by name, parsing the backend once.
+	// Returns error on fail or if backend does not exist.
+	GetServersByBackend(backend string, transactionID string) (int64, models.Servers, map[string]int, error)
+	// EditServers edits multiple servers of a backend using a single transaction save,
+	// data is keyed by the current server names. One of version or transactionID is
+	// mandatory. All servers are checked before any is changed, if one of them fails
+	// none is edited. Returns error on fail, nil on success.
+	EditServers(backend string, data map[string]*models.Server, transactionID string, version int64) error
 	// GetServerSwitchingRules returns configuration version and an array of
 	// configured server switching rules in the specified backend. Returns error on fail.
 	GetServerSwitchingRules(backend string, transactionID string) (int64, models.ServerSwitchingRules, error)
@@ -268,8 +511,9 @@ type IConfigurationClient interface {
 	// DeleteServerSwitchingRule deletes a server switching rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteServerSwitchingRule(id int64, backend string, transactionID string, version int64) error
-	// CreateServerSwitchingRule creates a server switching rule in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateServerSwitchingRule creates a server switching rule in configuration at data.Index, rules at
+	// that position and after it are moved down, a nil index appends the rule. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateServerSwitchingRule(backend string, data *models.ServerSwitchingRule, transactionID string, version int64) error
 	// EditServerSwitchingRule edits a server switching rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
@@ -303,12 +547,35 @@ type IConfigurationClient interface {
 	// DeleteStickRule deletes a stick rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteStickRule(id int64, backend string, transactionID string, version int64) error
-	// CreateStickRule creates a stick rule in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateStickRule creates a stick rule in configuration at data.Index, rules at that
+	// position and after it are moved down, a nil index appends the rule. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateStickRule(backend string, data *models.StickRule, transactionID string, version int64) error
 	// EditStickRule edits a stick rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	EditStickRule(id int64, backend string, data *models.StickRule, transactionID string, version int64) error
+	// GetStructuredConfiguration returns configuration version and the whole configuration
+	// with all sections and the objects they contain. All sections are read from the
+	// same parser. Returns error on fail.
+	GetStructuredConfiguration(transactionID string) (int64, *models.Configuration, error)
+	// PushStructuredConfiguration replaces the global and defaults sections and all frontends,
+	// backends, resolvers and peers sections with the ones in data, sections of other types are
+	// left as they are. Lines of existing sections the structured configuration has no model
+	// for are kept. Nothing is changed if any section can not be serialized. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
+	PushStructuredConfiguration(data *models.Configuration, transactionID string, version int64) error
+	// DiffTransactions returns the changes in the configuration of toTransactionID compared to
+	// the configuration of fromTransactionID, an empty transaction ID stands for the committed
+	// configuration. Returns error on fail.
+	DiffTransactions(fromTransactionID, toTransactionID string) (models.ConfigurationDiff, error)
+	// GetBackendReferences returns configuration version and the default_backend
+	// settings and use_backend rules referencing the backend, deleting a backend
+	// that is still referenced breaks the configuration. Returns error on fail or
+	// if the backend does not exist.
+	GetBackendReferences(backend string, transactionID string) (int64, models.ConfigurationReferences, error)
+	// GetServerReferences returns configuration version and the servers tracking
+	// the server of the backend. Returns error on fail or if the server does not exist.
+	GetServerReferences(name string, backend string, transactionID string) (int64, models.ConfigurationReferences, error)
 	// GetTCPRequestRules returns configuration version and an array of
 	// configured TCP request rules in the specified parent. Returns error on fail.
 	GetTCPRequestRules(parentType, parentName string, transactionID string) (int64, models.TCPRequestRules, error)
@@ -318,8 +585,9 @@ type IConfigurationClient interface {
 	// DeleteTCPRequestRule deletes a tcp request rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	DeleteTCPRequestRule(id int64, parentType string, parentName string, transactionID string, version int64) error
-	// CreateTCPRequestRule creates a tcp request rule in configuration. One of version or transactionID is
-	// mandatory. Returns error on fail, nil on success.
+	// CreateTCPRequestRule creates a tcp request rule in configuration at data.Index, rules at
+	// that position and after it are moved down, a nil index appends the rule. One of version
+	// or transactionID is mandatory. Returns error on fail, nil on success.
 	CreateTCPRequestRule(parentType string, parentName string, data *models.TCPRequestRule, transactionID string, version int64) error
 	// EditTCPRequestRule edits a tcp request rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
@@ -339,6 +607,41 @@ type IConfigurationClient interface {
 	// EditTCPResponseRule edits a tcp response rule in configuration. One of version or transactionID is
 	// mandatory. Returns error on fail, nil on success.
 	EditTCPResponseRule(id int64, backend string, data *models.TCPResponseRule, transactionID string, version int64) error
-	// GetConfigurationVersion returns configuration version
+	// WithTransaction starts a transaction on the current configuration version and
+	// calls fn with a handle to it. The transaction is committed if fn returns nil
+	// and deleted if fn returns an error or panics, so either all of the changes made
+	// through the handle are applied or none of them are. Returns the error of fn or
+	// of the commit.
+	WithTransaction(fn func(tx *configuration.TransactionHandle) error) error
+	// GetUsers returns configuration version and an array of
+	// configured users in the specified userlist. Returns error on fail.
+	GetUsers(userlist string, transactionID string) (int64, models.Users, error)
+	// GetUser returns configuration version and a requested user
+	// in the specified userlist. Returns error on fail or if user does not exist.
+	GetUser(username string, userlist string, transactionID string) (int64, *models.User, error)
+	// DeleteUser deletes a user in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	DeleteUser(username string, userlist string, transactionID string, version int64) error
+	// CreateUser creates a user in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateUser(userlist string, data *models.User, transactionID string, version int64) error
+	// EditUser edits a user in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	EditUser(username string, userlist string, data *models.User, transactionID string, version int64) error
+	// GetUserlists returns configuration version and an array of
+	// configured userlists. Returns error on fail.
+	GetUserlists(transactionID string) (int64, models.Userlists, error)
+	// GetUserlist returns configuration version and a requested userlist.
+	// Returns error on fail or if userlist does not exist.
+	GetUserlist(name string, transactionID string) (int64, *models.Userlist, error)
+	// DeleteUserlist deletes a userlist with its users and groups in configuration.
+	// One of version or transactionID is mandatory. Returns error on fail, nil on success.
+	DeleteUserlist(name string, transactionID string, version int64) error
+	// CreateUserlist creates a userlist in configuration. One of version or transactionID is
+	// mandatory. Returns error on fail, nil on success.
+	CreateUserlist(data *models.Userlist, transactionID string, version int64) error
+	// GetConfigurationVersion returns the version of the committed configuration,
+	// or of the transaction if transactionID is set. Only the version comment is
+	// read, sections are not parsed, so it is cheap enough for frequent polling.
 	GetConfigurationVersion(transactionID string) (int64, error)
 }
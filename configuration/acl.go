@@ -104,13 +104,17 @@ func (c *Client) DeleteACL(id int64, parentType string, parentName string, trans
 	return nil
 }
 
-// CreateACL creates a ACL line in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateACL creates a ACL line in configuration at data.Index, lines at that position
+// and after it are moved down, a nil index appends the line. One of version or
+// transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateACL(parentType string, parentName string, data *models.ACL, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -126,8 +130,13 @@ func (c *Client) CreateACL(parentType string, parentName string, data *models.AC
 		section = parser.Frontends
 	}
 
-	if err := p.Insert(section, parentName, "acl", SerializeACL(*data), int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), parentType, parentName, t, transactionID == "", err)
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
+	if err := p.Insert(section, parentName, "acl", SerializeACL(*data), int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -143,7 +152,10 @@ func (c *Client) EditACL(id int64, parentType string, parentName string, data *m
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
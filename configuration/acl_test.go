@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -203,3 +204,78 @@ func TestCreateEditDeleteACL(t *testing.T) {
 		version++
 	}
 }
+
+func TestACLsSharingName(t *testing.T) {
+	err := client.CreateBackend(&models.Backend{Name: "acl_shared_name"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	acls := []*models.ACL{
+		{Index: misc.Int64P(0), ACLName: "is_api", Criterion: "path_beg", Value: "/api"},
+		{Index: misc.Int64P(1), ACLName: "is_api", Criterion: "hdr(host)", Value: "-i api.example.com"},
+	}
+	for _, a := range acls {
+		if err := client.CreateACL("backend", "acl_shared_name", a, "", version); err != nil {
+			t.Fatal(err.Error())
+		}
+		version++
+	}
+
+	// without validation an ACL without index is appended
+	client.UseValidation = false
+	err = client.CreateACL("backend", "acl_shared_name", &models.ACL{ACLName: "is_static", Criterion: "path_end", Value: ".css .js"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetACLs("backend", "acl_shared_name", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(created) != 3 {
+		t.Fatalf("%v ACLs returned, expected 3", len(created))
+	}
+	for i, a := range acls {
+		if !reflect.DeepEqual(created[i], a) {
+			t.Errorf("ACL %v is %v, expected %v", i, *created[i], *a)
+		}
+	}
+
+	// editing the second line leaves the first one with the same name alone
+	edited := &models.ACL{Index: misc.Int64P(1), ACLName: "is_api", Criterion: "hdr(host)", Value: "-i api.example.org"}
+	if err := client.EditACL(1, "backend", "acl_shared_name", edited, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	// deleting the first line moves the second one up
+	if err := client.DeleteACL(0, "backend", "acl_shared_name", "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err = client.GetACLs("backend", "acl_shared_name", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(created) != 2 {
+		t.Fatalf("%v ACLs returned, expected 2", len(created))
+	}
+	edited.Index = misc.Int64P(0)
+	if !reflect.DeepEqual(created[0], edited) {
+		t.Errorf("ACL 0 is %v, expected %v", *created[0], *edited)
+	}
+	if created[1].ACLName != "is_static" {
+		t.Errorf("ACL 1 is %s, expected is_static", created[1].ACLName)
+	}
+
+	if err := client.DeleteBackend("acl_shared_name", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
@@ -94,7 +94,10 @@ func (c *Client) CreateBackend(data *models.Backend, transactionID string, versi
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	if err := c.createSection(parser.Backends, data.Name, data, transactionID, version); err != nil {
@@ -109,7 +112,10 @@ func (c *Client) EditBackend(name string, data *models.Backend, transactionID st
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	if err := c.editSection(parser.Backends, name, data, transactionID, version); err != nil {
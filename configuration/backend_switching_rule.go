@@ -90,13 +90,17 @@ func (c *Client) DeleteBackendSwitchingRule(id int64, frontend string, transacti
 	return nil
 }
 
-// CreateBackendSwitchingRule creates a backend switching rule in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateBackendSwitchingRule creates a backend switching rule in configuration at data.Index, rules at
+// that position and after it are moved down, a nil index appends the rule. One of version
+// or transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateBackendSwitchingRule(frontend string, data *models.BackendSwitchingRule, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -105,8 +109,13 @@ func (c *Client) CreateBackendSwitchingRule(frontend string, data *models.Backen
 		return err
 	}
 
-	if err := p.Insert(parser.Frontends, frontend, "use_backend", SerializeBackendSwitchingRule(*data), int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), "frontend", frontend, t, transactionID == "", err)
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
+	if err := p.Insert(parser.Frontends, frontend, "use_backend", SerializeBackendSwitchingRule(*data), int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), "frontend", frontend, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -122,7 +131,10 @@ func (c *Client) EditBackendSwitchingRule(id int64, frontend string, data *model
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
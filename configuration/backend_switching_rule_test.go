@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -181,3 +182,73 @@ func TestCreateEditDeleteBackendSwitchingRule(t *testing.T) {
 		version++
 	}
 }
+
+func TestBackendSwitchingRulesOrder(t *testing.T) {
+	err := client.CreateFrontend(&models.Frontend{Name: "backend_switching_order", DefaultBackend: "test"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	rules := []*models.BackendSwitchingRule{
+		{Index: misc.Int64P(0), Name: "test", Cond: "if", CondTest: "{ hdr(host) -i a.example.com }"},
+		{Index: misc.Int64P(1), Name: "test_2", Cond: "if", CondTest: "{ hdr(host) -i b.example.com }"},
+		// inserted between the two rules above
+		{Index: misc.Int64P(1), Name: "test_2", Cond: "unless", CondTest: "{ path_beg /static }"},
+		// inserted first
+		{Index: misc.Int64P(0), Name: "%[req.hdr(X-Backend)]", Cond: "if", CondTest: "{ req.hdr(X-Backend) -m found }"},
+	}
+	for _, r := range rules {
+		if err := client.CreateBackendSwitchingRule("backend_switching_order", r, "", version); err != nil {
+			t.Fatalf("%s: %s", r.Name, err.Error())
+		}
+		version++
+	}
+
+	// without validation a rule without index is appended
+	client.UseValidation = false
+	err = client.CreateBackendSwitchingRule("backend_switching_order", &models.BackendSwitchingRule{Name: "test", Cond: "if", CondTest: "TRUE"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetBackendSwitchingRules("backend_switching_order", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []string{
+		"{ req.hdr(X-Backend) -m found }",
+		"{ hdr(host) -i a.example.com }",
+		"{ path_beg /static }",
+		"{ hdr(host) -i b.example.com }",
+		"TRUE",
+	}
+	if len(created) != len(expected) {
+		t.Fatalf("%v backend switching rules returned, expected %v", len(created), len(expected))
+	}
+	for i, r := range created {
+		if *r.Index != int64(i) {
+			t.Errorf("Rule %v has index %v", i, *r.Index)
+		}
+		if r.CondTest != expected[i] {
+			t.Errorf("Rule %v has condition %s, expected %s", i, r.CondTest, expected[i])
+		}
+	}
+
+	// default_backend is not a switching rule and is left untouched
+	_, f, err := client.GetFrontend("backend_switching_order", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if f.DefaultBackend != "test" {
+		t.Errorf("Default backend %s, expected test", f.DefaultBackend)
+	}
+
+	if err := client.DeleteFrontend("backend_switching_order", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
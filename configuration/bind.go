@@ -16,8 +16,11 @@
 package configuration
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -33,6 +36,15 @@ import (
 // GetBinds returns configuration version and an array of
 // configured binds in the specified frontend. Returns error on fail.
 func (c *Client) GetBinds(frontend string, transactionID string) (int64, models.Binds, error) {
+	return c.GetBindsCtx(context.Background(), frontend, transactionID)
+}
+
+// GetBindsCtx is GetBinds that returns ctx.Err() as soon as ctx is done
+func (c *Client) GetBindsCtx(ctx context.Context, frontend string, transactionID string) (int64, models.Binds, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, nil, err
+	}
+
 	p, err := c.GetParser(transactionID)
 	if err != nil {
 		return 0, nil, err
@@ -54,6 +66,15 @@ func (c *Client) GetBinds(frontend string, transactionID string) (int64, models.
 // GetBind returns configuration version and a requested bind
 // in the specified frontend. Returns error on fail or if bind does not exist.
 func (c *Client) GetBind(name string, frontend string, transactionID string) (int64, *models.Bind, error) {
+	return c.GetBindCtx(context.Background(), name, frontend, transactionID)
+}
+
+// GetBindCtx is GetBind that returns ctx.Err() as soon as ctx is done
+func (c *Client) GetBindCtx(ctx context.Context, name string, frontend string, transactionID string) (int64, *models.Bind, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, nil, err
+	}
+
 	p, err := c.GetParser(transactionID)
 	if err != nil {
 		return 0, nil, err
@@ -64,6 +85,10 @@ func (c *Client) GetBind(name string, frontend string, transactionID string) (in
 		return 0, nil, err
 	}
 
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+	}
+
 	bind, _ := GetBindByName(name, frontend, p)
 	if bind == nil {
 		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind %s does not exist in frontend %s", name, frontend))
@@ -72,58 +97,599 @@ func (c *Client) GetBind(name string, frontend string, transactionID string) (in
 	return v, bind, nil
 }
 
+// GetAllBinds returns configuration version and the binds of every frontend,
+// keyed by frontend name. Returns error on fail.
+func (c *Client) GetAllBinds(transactionID string) (int64, map[string]models.Binds, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	binds, err := ParseAllBinds(p)
+	if err != nil {
+		return v, nil, c.HandleError("", "", "", "", false, err)
+	}
+
+	return v, binds, nil
+}
+
+// WalkBinds calls fn with each bind of every frontend, frontends sorted by name
+// and binds in configuration order. Binds are parsed one at a time, so unlike
+// GetAllBinds the memory used does not grow with the number of binds. The walk
+// stops at the first error returned by fn, or with ctx.Err() as soon as ctx is
+// done, and that error is returned. Returns configuration version and error on
+// fail, nil on success.
+func (c *Client) WalkBinds(ctx context.Context, transactionID string, fn func(frontend string, bind *models.Bind) error) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, err
+	}
+
+	frontends, err := p.SectionsGet(parser.Frontends)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrSectionMissing) {
+			return v, nil
+		}
+		return v, err
+	}
+	sort.Strings(frontends)
+
+	for _, frontend := range frontends {
+		data, err := p.Get(parser.Frontends, frontend, "bind", false)
+		if err != nil {
+			if errors.Is(err, parser_errors.ErrFetch) {
+				continue
+			}
+			return v, c.HandleError("", "frontend", frontend, "", false, err)
+		}
+		for _, ondiskBind := range data.([]types.Bind) {
+			if err := ctx.Err(); err != nil {
+				return v, err
+			}
+			b := ParseBind(ondiskBind)
+			if b == nil {
+				continue
+			}
+			if err := fn(frontend, b); err != nil {
+				return v, err
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// GetBindByAddress returns configuration version and a bind listening on the
+// given address and port in the specified frontend. Port should be nil for binds
+// without port, such as UNIX sockets, and the first port for port range binds.
+// Returns error on fail or if bind does not exist.
+func (c *Client) GetBindByAddress(address string, port *int64, frontend string, transactionID string) (int64, *models.Bind, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	binds, err := ParseBinds(frontend, p)
+	if err != nil {
+		return v, nil, c.HandleError("", "frontend", frontend, "", false, err)
+	}
+
+	for _, b := range binds {
+		if b.Address != address {
+			continue
+		}
+		if (b.Port == nil && port == nil) || (b.Port != nil && port != nil && *b.Port == *port) {
+			return v, b, nil
+		}
+	}
+
+	addr := address
+	if port != nil {
+		addr = fmt.Sprintf("%s:%d", address, *port)
+	}
+	return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind on %s does not exist in frontend %s", addr, frontend))
+}
+
 // DeleteBind deletes a bind in configuration. One of version or transactionID is
 // mandatory. Returns error on fail, nil on success.
 func (c *Client) DeleteBind(name string, frontend string, transactionID string, version int64) error {
-	p, t, err := c.loadDataForChange(transactionID, version)
+	return c.DeleteBindCtx(context.Background(), name, frontend, transactionID, version)
+}
+
+// DeleteBindCtx is DeleteBind that returns ctx.Err() if ctx is done before the
+// configuration is changed or, without transactionID, committed
+func (c *Client) DeleteBindCtx(ctx context.Context, name string, frontend string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChangeCtx(ctx, transactionID, version)
 	if err != nil {
 		return err
 	}
 
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError(name, "frontend", frontend, t, transactionID == "", e)
+	}
+
 	bind, i := GetBindByName(name, frontend, p)
 	if bind == nil {
 		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind %s does not exist in frontend %s", name, frontend))
 		return c.HandleError(name, "frontend", frontend, t, transactionID == "", e)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return c.HandleError(name, "frontend", frontend, t, transactionID == "", err)
+	}
+
 	if err := p.Delete(parser.Frontends, frontend, "bind", i); err != nil {
 		return c.HandleError(name, "frontend", frontend, t, transactionID == "", err)
 	}
 
-	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+	if err := c.SaveDataCtx(ctx, p, t, transactionID == ""); err != nil {
 		return err
 	}
 	return nil
 }
 
+// DeleteBindsWhere deletes all binds of the frontend for which match returns true
+// using a single transaction save. One of version or transactionID is mandatory.
+// Returns the number of deleted binds and error on fail, nil on success.
+func (c *Client) DeleteBindsWhere(frontend string, match func(*models.Bind) bool, transactionID string, version int64) (int, error) {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return 0, err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return 0, c.HandleError("", "frontend", frontend, t, transactionID == "", e)
+	}
+
+	binds, err := ParseBinds(frontend, p)
+	if err != nil {
+		return 0, c.HandleError("", "frontend", frontend, t, transactionID == "", err)
+	}
+
+	// delete from the last bind so the indexes of the ones left to delete do not move
+	deleted := 0
+	for i := len(binds) - 1; i >= 0; i-- {
+		if !match(binds[i]) {
+			continue
+		}
+		if err := p.Delete(parser.Frontends, frontend, "bind", i); err != nil {
+			return 0, c.HandleError(binds[i].Name, "frontend", frontend, t, transactionID == "", err)
+		}
+		deleted++
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
 // CreateBind creates a bind in configuration. One of version or transactionID is
 // mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateBind(frontend string, data *models.Bind, transactionID string, version int64) error {
+	return c.CreateBindAtCtx(context.Background(), frontend, -1, data, transactionID, version)
+}
+
+// CreateBindCtx is CreateBind that returns ctx.Err() if ctx is done before the
+// configuration is changed or, without transactionID, committed
+func (c *Client) CreateBindCtx(ctx context.Context, frontend string, data *models.Bind, transactionID string, version int64) error {
+	return c.CreateBindAtCtx(ctx, frontend, -1, data, transactionID, version)
+}
+
+// CreateBindAt creates a bind in configuration at the given position of the
+// frontend bind lines, negative index appends the bind. One of version or
+// transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateBindAt(frontend string, index int64, data *models.Bind, transactionID string, version int64) error {
+	return c.CreateBindAtCtx(context.Background(), frontend, index, data, transactionID, version)
+}
+
+// CreateBindAtCtx is CreateBindAt that returns ctx.Err() if ctx is done before the
+// configuration is changed or, without transactionID, committed
+func (c *Client) CreateBindAtCtx(ctx context.Context, frontend string, index int64, data *models.Bind, transactionID string, version int64) error {
+	if err := c.validateNewBind(data); err != nil {
+		return err
+	}
+
+	p, t, err := c.loadDataForChangeCtx(ctx, transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if err := insertBind(ctx, p, frontend, index, data); err != nil {
+		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveDataCtx(ctx, p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateBindEnsureFrontend creates a bind in configuration like CreateBind, but
+// first creates the frontend, with only its name, if it does not exist. Both are
+// created in the same transaction and if the bind can't be inserted the frontend
+// is not created either. One of version or transactionID is mandatory. Returns
+// error on fail, nil on success.
+func (c *Client) CreateBindEnsureFrontend(frontend string, data *models.Bind, transactionID string, version int64) error {
+	if err := c.validateNewBind(data); err != nil {
+		return err
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	created := false
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		if err := p.SectionsCreate(parser.Frontends, frontend); err != nil {
+			return c.HandleError(frontend, "", "", t, transactionID == "", err)
+		}
+		created = true
+	}
+
+	if err := insertBind(context.Background(), p, frontend, -1, data); err != nil {
+		// an explicit transaction is kept, so the frontend has to be removed from it
+		if created {
+			_ = p.SectionsDelete(parser.Frontends, frontend)
+		}
+		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateNewBind runs the validation of a bind about to be created, if enabled
+func (c *Client) validateNewBind(data *models.Bind) error {
+	if !c.UseValidation {
+		return nil
+	}
+	if validationErr := data.Validate(strfmt.Default); validationErr != nil {
+		return NewValidationError(validationErr)
+	}
+	if err := c.runValidators(data); err != nil {
+		return err
+	}
+	if c.SkipSemanticValidation {
+		return nil
+	}
+	return validateBind(data)
+}
+
+// insertBind checks that the bind can be created in the frontend and inserts it at
+// the given position of the frontend bind lines, negative index appends the bind.
+func insertBind(ctx context.Context, p *parser.Parser, frontend string, index int64, data *models.Bind) error {
+	if data.Port != nil && data.PortRangeEnd != nil && *data.Port >= *data.PortRangeEnd {
+		return NewConfError(ErrGeneralError, fmt.Sprintf("Bind port range end %d has to be greater start %d", *data.PortRangeEnd, *data.Port))
+	}
+
+	if bind, _ := GetBindByName(data.Name, frontend, p); bind != nil {
+		return NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Bind %s already exists in frontend %s", data.Name, frontend))
+	}
+
+	// an explicit transaction keeps the change even if SaveDataCtx fails, so ctx
+	// has to be checked before the parser is changed
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return p.Insert(parser.Frontends, frontend, "bind", SerializeBind(*data), int(index))
+}
+
+// CreateBinds creates multiple binds in configuration using a single transaction save.
+// One of version or transactionID is mandatory. All binds are checked before any is
+// inserted, if one of them fails none is created. Returns error on fail, nil on success.
+func (c *Client) CreateBinds(frontend string, data models.Binds, transactionID string, version int64) error {
+	if c.UseValidation {
+		if res := c.validateBindList(data); len(res) > 0 {
+			return CompositeTransactionError(res...)
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError("", "frontend", frontend, t, transactionID == "", e)
+	}
+
+	binds, err := ParseBinds(frontend, p)
+	if err != nil {
+		return c.HandleError("", "frontend", frontend, t, transactionID == "", err)
+	}
+	names := make(map[string]struct{}, len(binds)+len(data))
+	for _, b := range binds {
+		names[b.Name] = struct{}{}
+	}
+
+	res := []error{}
+	for _, b := range data {
+		if b.Port != nil && b.PortRangeEnd != nil && *b.Port >= *b.PortRangeEnd {
+			res = append(res, NewConfError(ErrGeneralError, fmt.Sprintf("Bind %s port range end %d has to be greater start %d", b.Name, *b.PortRangeEnd, *b.Port)))
+		}
+		if _, ok := names[b.Name]; ok {
+			res = append(res, NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Bind %s already exists in frontend %s", b.Name, frontend)))
+		}
+		names[b.Name] = struct{}{}
+	}
+	if len(res) > 0 {
+		return c.HandleError("", "frontend", frontend, t, transactionID == "", CompositeTransactionError(res...))
+	}
+
+	for i, b := range data {
+		if err := p.Insert(parser.Frontends, frontend, "bind", SerializeBind(*b), -1); err != nil {
+			// remove binds inserted so far so the transaction is left untouched
+			for j := len(binds) + i - 1; j >= len(binds); j-- {
+				_ = p.Delete(parser.Frontends, frontend, "bind", j)
+			}
+			return c.HandleError(b.Name, "frontend", frontend, t, transactionID == "", err)
+		}
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReplaceBinds replaces all binds in the frontend with the given ones, in the
+// given order. Binds that are not changed are kept as they are on disk, the rest
+// is created, edited or deleted in a single transaction save. One of version or
+// transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) ReplaceBinds(frontend string, data models.Binds, transactionID string, version int64) error {
+	if c.UseValidation {
+		if res := c.validateBindList(data); len(res) > 0 {
+			return CompositeTransactionError(res...)
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError("", "frontend", frontend, t, transactionID == "", e)
+	}
+
+	existing := map[string]types.Bind{}
+	ondisk, err := p.Get(parser.Frontends, frontend, "bind", false)
+	if err == nil {
+		for _, b := range ondisk.([]types.Bind) {
+			existing[bindName(b)] = b
+		}
+	}
+
+	res := []error{}
+	names := make(map[string]struct{}, len(data))
+	binds := make([]types.Bind, 0, len(data))
+	for _, b := range data {
+		if b.Port != nil && b.PortRangeEnd != nil && *b.Port >= *b.PortRangeEnd {
+			res = append(res, NewConfError(ErrGeneralError, fmt.Sprintf("Bind %s port range end %d has to be greater start %d", b.Name, *b.PortRangeEnd, *b.Port)))
+		}
+		if _, ok := names[b.Name]; ok {
+			res = append(res, NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Bind %s is given more than once", b.Name)))
+		}
+		names[b.Name] = struct{}{}
+
+		if e, ok := existing[b.Name]; ok && BindsEqual(ParseBind(e), b) {
+			binds = append(binds, e)
+			continue
+		}
+		binds = append(binds, SerializeBind(*b))
+	}
+	if len(res) > 0 {
+		return c.HandleError("", "frontend", frontend, t, transactionID == "", CompositeTransactionError(res...))
+	}
+
+	if err := p.Set(parser.Frontends, frontend, "bind", binds); err != nil {
+		return c.HandleError("", "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditBind edits a bind in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditBind(name string, frontend string, data *models.Bind, transactionID string, version int64) error {
+	return c.EditBindCtx(context.Background(), name, frontend, data, transactionID, version)
+}
+
+// EditBindCtx is EditBind that returns ctx.Err() if ctx is done before the
+// configuration is changed or, without transactionID, committed
+func (c *Client) EditBindCtx(ctx context.Context, name string, frontend string, data *models.Bind, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		if !c.SkipSemanticValidation {
+			if err := validateBind(data); err != nil {
+				return err
+			}
+		}
+	}
+	p, t, err := c.loadDataForChangeCtx(ctx, transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", e)
+	}
+
+	bind, i := GetBindByName(name, frontend, p)
+	if bind == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind %v does not exist in frontend %s", name, frontend))
+		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", e)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := p.Set(parser.Frontends, frontend, "bind", SerializeBind(*data), i); err != nil {
+		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveDataCtx(ctx, p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenameBind changes the name of a bind in configuration. Only the name option of
+// the bind line is changed, the bind keeps its position and all its other options.
+// One of version or transactionID is mandatory. Returns error on fail or if the
+// frontend already has a bind named newName, nil on success.
+func (c *Client) RenameBind(oldName, newName, frontend string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError(oldName, "frontend", frontend, t, transactionID == "", e)
+	}
+
+	bind, i := GetBindByName(oldName, frontend, p)
+	if bind == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind %s does not exist in frontend %s", oldName, frontend))
+		return c.HandleError(oldName, "frontend", frontend, t, transactionID == "", e)
+	}
+	if existing, _ := GetBindByName(newName, frontend, p); existing != nil {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Bind %s already exists in frontend %s", newName, frontend))
+		return c.HandleError(newName, "frontend", frontend, t, transactionID == "", e)
+	}
+
+	bind.Name = newName
+	if c.UseValidation {
+		if validationErr := bind.Validate(strfmt.Default); validationErr != nil {
+			return c.HandleError(newName, "frontend", frontend, t, transactionID == "", NewValidationError(validationErr))
+		}
+		if err := c.runValidators(bind); err != nil {
+			return c.HandleError(newName, "frontend", frontend, t, transactionID == "", err)
+		}
+	}
+
+	data, err := p.Get(parser.Frontends, frontend, "bind", false)
+	if err != nil {
+		return c.HandleError(oldName, "frontend", frontend, t, transactionID == "", err)
+	}
+	ondiskBind := data.([]types.Bind)[i]
+	ondiskBind.Params = renameBindOptions(ondiskBind.Params, newName)
+
+	if err := p.Set(parser.Frontends, frontend, "bind", ondiskBind, i); err != nil {
+		return c.HandleError(newName, "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// renameBindOptions returns a copy of options with the name option set to name,
+// a bind without name option gets one first as SerializeBind writes it
+func renameBindOptions(options []params.BindOption, name string) []params.BindOption {
+	res := make([]params.BindOption, 0, len(options)+1)
+	renamed := false
+	for _, option := range options {
+		if v, ok := option.(*params.BindOptionValue); ok && v.Name == "name" {
+			option = &params.BindOptionValue{Name: "name", Value: name}
+			renamed = true
+		}
+		res = append(res, option)
+	}
+	if !renamed {
+		res = append([]params.BindOption{&params.BindOptionValue{Name: "name", Value: name}}, res...)
+	}
+	return res
+}
+
+// CloneBind copies a bind to another frontend, renamed to newName unless it is
+// empty. One of version or transactionID is mandatory. Returns error on fail or
+// if the destination frontend already has a bind with that name, nil on success.
+func (c *Client) CloneBind(name, srcFrontend, dstFrontend, newName string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	for _, frontend := range []string{srcFrontend, dstFrontend} {
+		if !c.checkSectionExists(parser.Frontends, frontend, p) {
+			e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+			return c.HandleError(name, "frontend", frontend, t, transactionID == "", e)
 		}
 	}
 
-	p, t, err := c.loadDataForChange(transactionID, version)
-	if err != nil {
-		return err
+	bind, _ := GetBindByName(name, srcFrontend, p)
+	if bind == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind %s does not exist in frontend %s", name, srcFrontend))
+		return c.HandleError(name, "frontend", srcFrontend, t, transactionID == "", e)
+	}
+	if newName != "" {
+		bind.Name = newName
 	}
 
-	if data.PortRangeEnd != nil && *data.Port >= *data.PortRangeEnd {
-		e := NewConfError(ErrGeneralError, fmt.Sprintf("Bind port range end %d has to be greater start %d", *data.PortRangeEnd, *data.Port))
-		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", e)
+	if c.UseValidation {
+		if validationErr := bind.Validate(strfmt.Default); validationErr != nil {
+			return c.HandleError(bind.Name, "frontend", dstFrontend, t, transactionID == "", NewValidationError(validationErr))
+		}
+		if err := c.runValidators(bind); err != nil {
+			return c.HandleError(bind.Name, "frontend", dstFrontend, t, transactionID == "", err)
+		}
 	}
 
-	bind, _ := GetBindByName(data.Name, frontend, p)
-	if bind != nil {
-		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Bind %s already exists in frontend %s", data.Name, frontend))
-		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", e)
+	if existing, _ := GetBindByName(bind.Name, dstFrontend, p); existing != nil {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Bind %s already exists in frontend %s", bind.Name, dstFrontend))
+		return c.HandleError(bind.Name, "frontend", dstFrontend, t, transactionID == "", e)
 	}
 
-	if err := p.Insert(parser.Frontends, frontend, "bind", SerializeBind(*data), -1); err != nil {
-		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", err)
+	if err := p.Insert(parser.Frontends, dstFrontend, "bind", SerializeBind(*bind), -1); err != nil {
+		return c.HandleError(bind.Name, "frontend", dstFrontend, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -133,37 +699,121 @@ func (c *Client) CreateBind(frontend string, data *models.Bind, transactionID st
 	return nil
 }
 
-// EditBind edits a bind in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
-func (c *Client) EditBind(name string, frontend string, data *models.Bind, transactionID string, version int64) error {
-	if c.UseValidation {
-		validationErr := data.Validate(strfmt.Default)
-		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+// validateBindList validates every bind in the list and returns all errors found
+func (c *Client) validateBindList(data models.Binds) []error {
+	res := []error{}
+	for _, b := range data {
+		if validationErr := b.Validate(strfmt.Default); validationErr != nil {
+			confErr := NewValidationError(validationErr)
+			confErr.msg = fmt.Sprintf("Bind %s: %s", b.Name, confErr.msg)
+			res = append(res, confErr)
+			continue
+		}
+		if err := c.runValidators(b); err != nil {
+			res = append(res, err)
+			continue
+		}
+		if c.SkipSemanticValidation {
+			continue
+		}
+		if err := validateBind(b); err != nil {
+			res = append(res, err)
 		}
 	}
-	p, t, err := c.loadDataForChange(transactionID, version)
-	if err != nil {
-		return err
-	}
+	return res
+}
 
-	bind, i := GetBindByName(name, frontend, p)
-	if bind == nil {
-		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind %v does not exist in frontend %s", name, frontend))
-		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", e)
-	}
+// ValidateBind checks a bind against the model schema and the semantic rules
+// applied when it is created or edited, without loading any configuration, so
+// that input can be checked before a transaction is started. Returns a
+// ConfError with the ErrValidationError code on fail, nil on success.
+func ValidateBind(data *models.Bind) error {
+	if err := data.Validate(strfmt.Default); err != nil {
+		return NewValidationError(err)
+	}
+	if data.Port != nil && data.PortRangeEnd != nil && *data.Port >= *data.PortRangeEnd {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: port range end %d has to be greater start %d", data.Name, *data.PortRangeEnd, *data.Port))
+	}
+	return validateBind(data)
+}
 
-	if err := p.Set(parser.Frontends, frontend, "bind", SerializeBind(*data), i); err != nil {
-		return c.HandleError(data.Name, "frontend", frontend, t, transactionID == "", err)
+// validateBind checks option combinations of a bind that can not be
+// expressed in the model schema.
+func validateBind(data *models.Bind) error {
+	// HAProxy knows only v4v6 and v6only, default is to follow the system setting
+	if data.V4v6 && data.V6only {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: v4v6 and v6only are mutually exclusive", data.Name))
+	}
+	for option, path := range map[string]string{"crt": data.SslCertificate, "crt-list": data.CrtList, "ca-file": data.SslCafile} {
+		if strings.ContainsAny(path, " \t\r\n") {
+			return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: %s path %q can not contain whitespace", data.Name, option, path))
+		}
 	}
-
-	if err := c.SaveData(p, t, transactionID == ""); err != nil {
-		return err
+	if data.StrictSni && !data.Ssl {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: strict-sni requires ssl", data.Name))
+	}
+	// early data is a TLSv1.3 feature
+	if data.Allow0rtt && (!data.Ssl || data.NoTlsv13 || (data.SslMaxVer != "" && data.SslMaxVer != models.BindSslMaxVerTLSv13)) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: allow-0rtt requires ssl with TLSv1.3 enabled", data.Name))
+	}
+	// CA names are sent only when client certificates are requested
+	if data.NoCaNames && (!data.Ssl || data.SslCafile == "" || data.Verify == "" || data.Verify == models.BindVerifyNone) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: no-ca-names requires ssl with ca-file and verify optional or required", data.Name))
+	}
+	if (data.Curves != "" || data.Ecdhe != "") && !data.Ssl {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: curves and ecdhe require ssl", data.Name))
+	}
+	if data.PreferClientCiphers && !data.Ssl {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: prefer-client-ciphers requires ssl", data.Name))
+	}
+	// certificates are generated with the CA given by ca-sign-file, falling
+	// back to ca-file or the bind certificate
+	if data.GenerateCertificates && (!data.Ssl || (data.CaSignFile == "" && data.SslCafile == "" && data.SslCertificate == "")) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: generate-certificates requires ssl and one of ca-sign-file, ca-file or crt", data.Name))
+	}
+	if data.SslCertificate != "" && data.CrtList != "" {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: crt and crt-list are mutually exclusive", data.Name))
+	}
+	if strings.Contains(data.Address, ",") && (data.Port != nil || data.PortRangeEnd != nil) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: ports of a bind with several addresses are given in the address", data.Name))
+	}
+	if isBindSocketAddress(data.Address) && (data.Port != nil || data.PortRangeEnd != nil) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: address %s has no port", data.Name, data.Address))
+	}
+	if data.Port == nil && data.PortRangeEnd != nil {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: port range end %d requires a port", data.Name, *data.PortRangeEnd))
+	}
+	// socket ownership and permissions apply only to UNIX sockets
+	if !bindIsUnixSocket(data) && (data.User != "" || data.Group != "" || data.Mode != "" || data.UID != "" || data.Gid != 0) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Bind %s: user, group, mode, uid and gid can only be set on UNIX socket binds", data.Name))
 	}
-
 	return nil
 }
 
+// bindIsUnixSocket returns true if bind listens on a UNIX socket path
+func bindIsUnixSocket(b *models.Bind) bool {
+	return strings.HasPrefix(b.Address, "/") || strings.HasPrefix(b.Address, "unix@")
+}
+
+// bindSocketPrefixes are the address prefixes of binds listening on an
+// inherited file descriptor, a socket pair or a UNIX socket, these addresses
+// have no port
+var bindSocketPrefixes = []string{"fd@", "sockpair@", "abns@", "unix@"}
+
+// isBindSocketAddress returns true if address has no port, it is a path or
+// has one of bindSocketPrefixes
+func isBindSocketAddress(address string) bool {
+	if strings.HasPrefix(address, "/") {
+		return true
+	}
+	for _, prefix := range bindSocketPrefixes {
+		if strings.HasPrefix(address, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func ParseBinds(frontend string, p *parser.Parser) (models.Binds, error) {
 	binds := models.Binds{}
 
@@ -185,28 +835,47 @@ func ParseBinds(frontend string, p *parser.Parser) (models.Binds, error) {
 	return binds, nil
 }
 
+// ParseAllBinds returns the binds of every frontend keyed by frontend name,
+// frontends without binds are present with an empty list
+func ParseAllBinds(p *parser.Parser) (map[string]models.Binds, error) {
+	res := map[string]models.Binds{}
+
+	frontends, err := p.SectionsGet(parser.Frontends)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrSectionMissing) {
+			return res, nil
+		}
+		return nil, err
+	}
+
+	for _, frontend := range frontends {
+		binds, err := ParseBinds(frontend, p)
+		if err != nil {
+			return nil, err
+		}
+		res[frontend] = binds
+	}
+	return res, nil
+}
+
 func ParseBind(ondiskBind types.Bind) *models.Bind { //nolint:gocognit,gocyclo
 	b := &models.Bind{
-		Name: ondiskBind.Path,
+		Name:    ondiskBind.Path,
+		Comment: ondiskBind.Comment,
 	}
-	if strings.HasPrefix(ondiskBind.Path, "/") {
+	switch {
+	case isBindSocketAddress(ondiskBind.Path):
+		// paths and sockets, "abns@name:1" is a name and not a port
 		b.Address = ondiskBind.Path
-	} else {
-		addSlice := strings.Split(ondiskBind.Path, ":")
-		switch n := len(addSlice); {
-		case n == 0:
-			return nil
-		case n == 4: // :::443
-			b.Address = "::"
-			if addSlice[3] != "" {
-				p, err := strconv.ParseInt(addSlice[3], 10, 64)
-				if err == nil {
-					b.Port = &p
-				}
-			}
-		case n > 1:
-			b.Address = addSlice[0]
-			ports := strings.Split(addSlice[1], "-")
+	case strings.Contains(ondiskBind.Path, ","):
+		// several addresses sharing the options, each one with its own port,
+		// are kept as they are in the address
+		b.Address = ondiskBind.Path
+	default:
+		address, portStr, ok := splitHostPort(ondiskBind.Path)
+		b.Address = address
+		if ok {
+			ports := strings.Split(portStr, "-")
 
 			// *:<port>
 			if ports[0] != "" {
@@ -223,15 +892,12 @@ func ParseBind(ondiskBind types.Bind) *models.Bind { //nolint:gocognit,gocyclo
 					b.PortRangeEnd = &portRangeEnd
 				}
 			}
-		case n > 0:
-			b.Address = addSlice[0]
-
 		}
 	}
 	for _, p := range ondiskBind.Params {
 		switch v := p.(type) {
 		case *params.BindOptionDoubleWord:
-			if v.Name == "expose-fd" && v.Value == "listener" {
+			if v.Name == "expose-fd" && v.Value == "listeners" {
 				b.ExposeFdListeners = true
 			}
 		case *params.BindOptionWord:
@@ -308,11 +974,16 @@ func ParseBind(ondiskBind types.Bind) *models.Bind { //nolint:gocognit,gocyclo
 					b.AcceptNetscalerCip = mn
 				}
 			case "backlog":
-				b.Backlog = v.Value
+				l, err := strconv.ParseInt(v.Value, 10, 64)
+				if err == nil {
+					b.Backlog = &l
+				}
 			case "curves":
 				b.Curves = v.Value
 			case "ecdhe":
 				b.Ecdhe = v.Value
+			case "ca-verify-file":
+				b.CaVerifyFile = v.Value
 			case "ca-ignore-err":
 				b.CaIgnoreErr = v.Value
 			case "ca-sign-file":
@@ -337,7 +1008,10 @@ func ParseBind(ondiskBind types.Bind) *models.Bind { //nolint:gocognit,gocyclo
 			case "group":
 				b.Group = v.Value
 			case "id":
-				b.ID = v.Value
+				id, err := strconv.ParseInt(v.Value, 10, 64)
+				if err == nil {
+					b.ID = &id
+				}
 			case "interface":
 				b.Interface = v.Value
 			case "level":
@@ -346,13 +1020,17 @@ func ParseBind(ondiskBind types.Bind) *models.Bind { //nolint:gocognit,gocyclo
 				b.SeverityOutput = v.Value
 			case "maxconn":
 				m, err := strconv.ParseInt(v.Value, 10, 64)
-				if err == nil && m != 0 {
-					b.Maxconn = m
+				if err == nil {
+					b.Maxconn = &m
 				}
 			case "mode":
 				b.Mode = v.Value
 			case "mss":
-				b.Mss = v.Value
+				// negative values reduce the MSS advertised by the client
+				mss, err := strconv.ParseInt(v.Value, 10, 64)
+				if err == nil && mss != 0 {
+					b.Mss = &mss
+				}
 			case "namespace":
 				b.Namespace = v.Value
 			case "nice":
@@ -380,11 +1058,26 @@ func ParseBind(ondiskBind types.Bind) *models.Bind { //nolint:gocognit,gocyclo
 	return b
 }
 
+// SerializeBind converts a bind model into its on-disk representation.
+// Bind options are always written in the same canonical order so that
+// re-serializing an unchanged bind produces an identical line:
+//   - identification: name, id, process
+//   - listening socket: interface, namespace, transparent, v4v6, v6only, tfo,
+//     defer-accept, backlog, maxconn, mss, tcp-ut, nice, accept-proxy,
+//     accept-netscaler-cip, expose-fd
+//   - UNIX socket: user, uid, group, gid, mode, level, severity-output
+//   - SSL: ssl, crt, crt-list, crt-ignore-err, ca-file, ca-verify-file,
+//     ca-ignore-err, ca-sign-file, ca-sign-pass, crl-file, verify, ssl-min-ver,
+//     ssl-max-ver, force-*, no-sslv3, no-tlsv*, ciphers, ciphersuites, curves,
+//     ecdhe, alpn, npn, strict-sni, generate-certificates, no-ca-names,
+//     no-tls-tickets, tls-ticket-keys, prefer-client-ciphers, allow-0rtt
+//   - protocol: proto
 func SerializeBind(b models.Bind) types.Bind { //nolint:gocognit,gocyclo
 	bind := types.Bind{
-		Params: []params.BindOption{},
+		Params:  []params.BindOption{},
+		Comment: b.Comment,
 	}
-	if b.Port != nil {
+	if b.Port != nil && !isBindSocketAddress(b.Address) {
 		bind.Path = b.Address + ":" + strconv.FormatInt(*b.Port, 10)
 		if b.PortRangeEnd != nil {
 			bind.Path = bind.Path + "-" + strconv.FormatInt(*b.PortRangeEnd, 10)
@@ -392,202 +1085,282 @@ func SerializeBind(b models.Bind) types.Bind { //nolint:gocognit,gocyclo
 	} else {
 		bind.Path = b.Address
 	}
+	// identification
 	if b.Name != "" {
 		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "name", Value: b.Name})
 	} else {
 		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "name", Value: bind.Path})
 	}
+	if b.ID != nil {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "id", Value: strconv.FormatInt(*b.ID, 10)})
+	}
 	if b.Process != "" {
 		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "process", Value: b.Process})
 	}
-	if b.SslCertificate != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "crt", Value: b.SslCertificate})
-	}
-	if b.SslCafile != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-file", Value: b.SslCafile})
+	// listening socket
+	if b.Interface != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "interface", Value: b.Interface})
 	}
-	if b.TCPUserTimeout != nil {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "tcp-ut", Value: strconv.FormatInt(*b.TCPUserTimeout, 10)})
+	if b.Namespace != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "namespace", Value: b.Namespace})
 	}
-	if b.Ssl {
-		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "ssl"})
+	if b.Transparent {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "transparent"})
 	}
 	if b.V4v6 {
 		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "v4v6"})
 	}
-	if b.Verify != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "verify", Value: b.Verify})
+	if b.V6only {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "v6only"})
 	}
-	if b.Alpn != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "alpn", Value: b.Alpn})
+	if b.Tfo {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "tfo"})
 	}
-	if b.Transparent {
-		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "transparent"})
+	if b.DeferAccept {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "defer-accept"})
+	}
+	if b.Backlog != nil {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "backlog", Value: strconv.FormatInt(*b.Backlog, 10)})
+	}
+	if b.Maxconn != nil {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "maxconn", Value: strconv.FormatInt(*b.Maxconn, 10)})
+	}
+	if b.Mss != nil {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "mss", Value: strconv.FormatInt(*b.Mss, 10)})
+	}
+	if b.TCPUserTimeout != nil {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "tcp-ut", Value: strconv.FormatInt(*b.TCPUserTimeout, 10)})
+	}
+	if b.Nice != 0 {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "nice", Value: strconv.FormatInt(b.Nice, 10)})
 	}
 	if b.AcceptProxy {
 		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "accept-proxy"})
 	}
-	if b.Allow0rtt {
-		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "allow-0rtt"})
-	}
 	if b.AcceptNetscalerCip != 0 {
 		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "accept-netscaler-cip", Value: strconv.FormatInt(b.AcceptNetscalerCip, 10)})
 	}
-	if b.Backlog != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "backlog", Value: b.Backlog})
+	if b.ExposeFdListeners {
+		bind.Params = append(bind.Params, &params.BindOptionDoubleWord{Name: "expose-fd", Value: "listeners"})
 	}
-	if b.Curves != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "curves", Value: b.Curves})
+	// UNIX socket
+	if b.User != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "user", Value: b.User})
 	}
-	if b.Ecdhe != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ecdhe", Value: b.Ecdhe})
+	if b.UID != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "uid", Value: b.UID})
 	}
-	if b.CaIgnoreErr != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-ignore-err", Value: b.CaIgnoreErr})
+	if b.Group != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "group", Value: b.Group})
 	}
-	if b.CaSignFile != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-sign-file", Value: b.CaSignFile})
+	if b.Gid != 0 {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "gid", Value: strconv.FormatInt(b.Gid, 10)})
 	}
-	if b.CaSignPass != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-sign-pass", Value: b.CaSignPass})
+	if b.Mode != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "mode", Value: b.Mode})
 	}
-	if b.Ciphers != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ciphers ", Value: b.Ciphers})
+	if b.Level != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "level", Value: b.Level})
 	}
-	if b.Ciphersuites != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ciphersuites ", Value: b.Ciphersuites})
+	if b.SeverityOutput != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "severity-output", Value: b.SeverityOutput})
 	}
-	if b.CrlFile != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "crl-file ", Value: b.CrlFile})
+	// SSL
+	if b.Ssl {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "ssl"})
 	}
-	if b.CrtIgnoreErr != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "crt-ignore-err", Value: b.CrtIgnoreErr})
+	if b.SslCertificate != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "crt", Value: b.SslCertificate})
 	}
 	if b.CrtList != "" {
 		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "crt-list", Value: b.CrtList})
 	}
-	if b.DeferAccept {
-		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "defer-accept"})
-	}
-	if b.ExposeFdListeners {
-		bind.Params = append(bind.Params, &params.ServerOptionDoubleWord{Name: "expose-fd", Value: "listeners"})
-	}
-	if b.ForceSslv3 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "force-sslv3"})
-	}
-	if b.ForceTlsv10 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "force-tlsv10"})
-	}
-	if b.ForceTlsv11 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "force-tlsv11"})
+	if b.CrtIgnoreErr != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "crt-ignore-err", Value: b.CrtIgnoreErr})
 	}
-	if b.ForceTlsv12 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "force-tlsv12"})
+	if b.SslCafile != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-file", Value: b.SslCafile})
 	}
-	if b.ForceTlsv13 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "force-tlsv13"})
+	if b.CaVerifyFile != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-verify-file", Value: b.CaVerifyFile})
 	}
-	if b.GenerateCertificates {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "generate-certificates"})
+	if b.CaIgnoreErr != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-ignore-err", Value: b.CaIgnoreErr})
 	}
-	if b.Gid != 0 {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "gid", Value: strconv.FormatInt(b.Gid, 10)})
+	if b.CaSignFile != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-sign-file", Value: b.CaSignFile})
 	}
-	if b.Group != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "group", Value: b.Group})
+	if b.CaSignPass != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ca-sign-pass", Value: b.CaSignPass})
 	}
-	if b.ID != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "id", Value: b.ID})
+	if b.CrlFile != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "crl-file", Value: b.CrlFile})
 	}
-	if b.Interface != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "interface", Value: b.Interface})
+	if b.Verify != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "verify", Value: b.Verify})
 	}
-	if b.Level != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "level", Value: b.Level})
+	if b.SslMinVer != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ssl-min-ver", Value: b.SslMinVer})
 	}
-	if b.SeverityOutput != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "severity-output", Value: b.SeverityOutput})
+	if b.SslMaxVer != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ssl-max-ver", Value: b.SslMaxVer})
 	}
-	if b.Maxconn != 0 {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "maxconn", Value: strconv.FormatInt(b.Maxconn, 10)})
+	if b.ForceSslv3 {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "force-sslv3"})
 	}
-	if b.Mode != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "mode", Value: b.Mode})
+	if b.ForceTlsv10 {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "force-tlsv10"})
 	}
-	if b.Mss != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "mss", Value: b.Mss})
+	if b.ForceTlsv11 {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "force-tlsv11"})
 	}
-	if b.Namespace != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "namespace", Value: b.Namespace})
+	if b.ForceTlsv12 {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "force-tlsv12"})
 	}
-	if b.NoCaNames {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "no-ca-names"})
+	if b.ForceTlsv13 {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "force-tlsv13"})
 	}
 	if b.NoSslv3 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "no-sslv3"})
-	}
-	if b.NoTLSTickets {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "no-tls-tickets"})
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "no-sslv3"})
 	}
 	if b.NoTlsv10 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "no-tlsv10"})
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "no-tlsv10"})
 	}
 	if b.NoTlsv11 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "no-tlsv11"})
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "no-tlsv11"})
 	}
 	if b.NoTlsv12 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "no-tlsv12"})
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "no-tlsv12"})
 	}
 	if b.NoTlsv13 {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "no-tlsv13"})
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "no-tlsv13"})
 	}
-	if b.Npn != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "npn", Value: b.Npn})
+	if b.Ciphers != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ciphers", Value: b.Ciphers})
 	}
-	if b.PreferClientCiphers {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "prefer-client-ciphers"})
+	if b.Ciphersuites != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ciphersuites", Value: b.Ciphersuites})
 	}
-	if b.Proto != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "proto", Value: b.Proto})
+	if b.Curves != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "curves", Value: b.Curves})
 	}
-	if b.SslMaxVer != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ssl-max-ver", Value: b.SslMaxVer})
+	if b.Ecdhe != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ecdhe", Value: b.Ecdhe})
 	}
-	if b.SslMinVer != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "ssl-min-ver", Value: b.SslMinVer})
+	if b.Alpn != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "alpn", Value: b.Alpn})
+	}
+	if b.Npn != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "npn", Value: b.Npn})
 	}
 	if b.StrictSni {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "strict-sni"})
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "strict-sni"})
 	}
-	if b.Tfo {
-		bind.Params = append(bind.Params, &params.ServerOptionWord{Name: "tfo"})
+	if b.GenerateCertificates {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "generate-certificates"})
+	}
+	if b.NoCaNames {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "no-ca-names"})
+	}
+	if b.NoTLSTickets {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "no-tls-tickets"})
 	}
 	if b.TLSTicketKeys != "" {
 		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "tls-ticket-keys", Value: b.TLSTicketKeys})
 	}
-	if b.V6only {
-		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "v6only"})
+	if b.PreferClientCiphers {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "prefer-client-ciphers"})
 	}
-	if b.UID != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "uid", Value: b.UID})
+	if b.Allow0rtt {
+		bind.Params = append(bind.Params, &params.BindOptionWord{Name: "allow-0rtt"})
 	}
-	if b.User != "" {
-		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "user", Value: b.User})
+	// protocol
+	if b.Proto != "" {
+		bind.Params = append(bind.Params, &params.BindOptionValue{Name: "proto", Value: b.Proto})
 	}
 
 	return bind
 }
 
+// GetBindByName returns the bind with the given name and its index in the frontend.
+// Only the matching bind line is fully parsed.
 func GetBindByName(name string, frontend string, p *parser.Parser) (*models.Bind, int) {
-	binds, err := ParseBinds(frontend, p)
+	data, err := p.Get(parser.Frontends, frontend, "bind", false)
 	if err != nil {
 		return nil, 0
 	}
 
-	for i, b := range binds {
-		if b.Name == name {
-			return b, i
+	for i, ondiskBind := range data.([]types.Bind) {
+		if bindName(ondiskBind) == name {
+			return ParseBind(ondiskBind), i
 		}
 	}
 	return nil, 0
 }
+
+// bindName returns the name ParseBind would give the on-disk bind
+// without parsing the remaining bind options.
+func bindName(ondiskBind types.Bind) string {
+	name := ondiskBind.Path
+	for _, p := range ondiskBind.Params {
+		if v, ok := p.(*params.BindOptionValue); ok && v.Name == "name" {
+			name = v.Value
+		}
+	}
+	return name
+}
+
+// BindsEqual returns true if both binds produce the same bind line, see DiffBinds
+func BindsEqual(a, b *models.Bind) bool {
+	return len(DiffBinds(a, b)) == 0
+}
+
+// DiffBinds returns the json names of the bind fields that differ between a and b.
+// A nil pointer differs from a pointer to a zero value, "maxconn 0" is not the
+// same as no maxconn. A nil bind is equal to an empty one, and error lists of
+// ca-ignore-err and crt-ignore-err are compared regardless of their order.
+func DiffBinds(a, b *models.Bind) []string {
+	if a == nil {
+		a = &models.Bind{}
+	}
+	if b == nil {
+		b = &models.Bind{}
+	}
+	va := reflect.ValueOf(*a)
+	vb := reflect.ValueOf(*b)
+	diff := []string{}
+	for i := 0; i < va.NumField(); i++ {
+		field := va.Type().Field(i)
+		fa := va.Field(i)
+		fb := vb.Field(i)
+		if fa.Kind() == reflect.Ptr {
+			if fa.IsNil() || fb.IsNil() {
+				if fa.IsNil() != fb.IsNil() {
+					diff = append(diff, strings.Split(field.Tag.Get("json"), ",")[0])
+				}
+				continue
+			}
+			fa = fa.Elem()
+			fb = fb.Elem()
+		}
+		equal := reflect.DeepEqual(fa.Interface(), fb.Interface())
+		if !equal && (field.Name == "CaIgnoreErr" || field.Name == "CrtIgnoreErr") {
+			equal = sameCommaList(fa.String(), fb.String())
+		}
+		if !equal {
+			diff = append(diff, strings.Split(field.Tag.Get("json"), ",")[0])
+		}
+	}
+	return diff
+}
+
+func sameCommaList(a, b string) bool {
+	la := strings.Split(a, ",")
+	lb := strings.Split(b, ",")
+	if len(la) != len(lb) {
+		return false
+	}
+	sort.Strings(la)
+	sort.Strings(lb)
+	return reflect.DeepEqual(la, lb)
+}
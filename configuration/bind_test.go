@@ -16,10 +16,19 @@
 package configuration
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/params"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -86,16 +95,78 @@ func TestGetBind(t *testing.T) {
 	_, _, err = client.GetBind("webserv", "test_2", "")
 	if err == nil {
 		t.Error("Should throw error, non existent bind")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectDoesNotExist {
+		t.Errorf("Should throw ErrObjectDoesNotExist error, got: %v", err)
+	}
+
+	_, _, err = client.GetBind("webserv", "doesnotexist", "")
+	if err == nil {
+		t.Error("Should throw error, non existent frontend")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Should throw ErrParentDoesNotExist error, got: %v", err)
+	}
+}
+
+func TestGetBindByAddress(t *testing.T) {
+	port := int64(8080)
+	_, b, err := client.GetBindByAddress("192.168.1.1", &port, "test", "")
+	if err != nil {
+		t.Error(err.Error())
+	} else if b.Name != "webserv2" {
+		t.Errorf("Expected bind webserv2, %v found", b.Name)
+	}
+
+	port = int64(8081)
+	_, _, err = client.GetBindByAddress("192.168.1.1", &port, "test", "")
+	if err == nil {
+		t.Error("Should throw error, non existent bind")
+	}
+
+	rangeStart := int64(10000)
+	rangeEnd := int64(10010)
+	binds := models.Binds{
+		&models.Bind{Name: "socket", Address: "/var/run/haproxy-test.sock"},
+		&models.Bind{Name: "range", Address: "192.168.2.1", Port: &rangeStart, PortRangeEnd: &rangeEnd},
+	}
+	err = client.CreateBinds("test", binds, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, b, err = client.GetBindByAddress("/var/run/haproxy-test.sock", nil, "test", "")
+	if err != nil {
+		t.Error(err.Error())
+	} else if b.Name != "socket" {
+		t.Errorf("Expected bind socket, %v found", b.Name)
+	}
+
+	_, b, err = client.GetBindByAddress("192.168.2.1", &rangeStart, "test", "")
+	if err != nil {
+		t.Error(err.Error())
+	} else if b.Name != "range" || *b.PortRangeEnd != rangeEnd {
+		t.Errorf("Expected bind range, %v found", b.Name)
+	}
+
+	for _, name := range []string{"socket", "range"} {
+		err = client.DeleteBind(name, "test", "", version)
+		if err != nil {
+			t.Error(err.Error())
+		} else {
+			version++
+		}
 	}
 }
 
 func TestCreateEditDeleteBind(t *testing.T) {
 	// TestCreateBind
 	port := int64(4300)
+	id := int64(42)
 	l := &models.Bind{
 		Name:           "created",
 		Address:        "192.168.2.1",
 		Port:           &port,
+		ID:             &id,
 		Ssl:            true,
 		SslCertificate: "dummy.crt",
 		Interface:      "eth0",
@@ -185,9 +256,1953 @@ func TestCreateEditDeleteBind(t *testing.T) {
 		t.Error("DeleteBind failed, bind test still exists")
 	}
 
-	err = client.DeleteBind("created", "test2", "", version)
+	// TestCreateUnixSocketBind
+	l = &models.Bind{
+		Name:    "socket",
+		Address: "/var/run/haproxy-test.sock",
+		User:    "haproxy",
+		Group:   "admin",
+		Mode:    "660",
+		UID:     "99",
+		Gid:     99,
+	}
+
+	err = client.CreateBind("test", l, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, bind, err = client.GetBind("socket", "test", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(bind, l) {
+		fmt.Printf("Created bind: %v\n", bind)
+		fmt.Printf("Given bind: %v\n", l)
+		t.Error("Created bind not equal to given bind")
+	}
+
+	err = client.DeleteBind("socket", "test", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	err = client.DeleteBind("created", "test", "", version)
 	if err == nil {
 		t.Error("Should throw error, non existent bind")
 		version++
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectDoesNotExist {
+		t.Errorf("Should throw ErrObjectDoesNotExist error, got: %v", err)
+	}
+
+	err = client.DeleteBind("created", "test2", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existent frontend")
+		version++
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Should throw ErrParentDoesNotExist error, got: %v", err)
+	}
+}
+
+// bindRoundTrip serializes a bind into its on-disk bind line options and
+// parses it back the same way the config parser would when loading the file.
+func bindRoundTrip(b models.Bind) (*models.Bind, string) {
+	ondisk := SerializeBind(b)
+	line := params.BindOptionsString(ondisk.Params)
+	parsed := ParseBind(types.Bind{
+		Path:   ondisk.Path,
+		Params: params.ParseBindOptions(strings.Fields(line)),
+	})
+	return parsed, line
+}
+
+type bindRoundTripTest struct {
+	name     string
+	bind     models.Bind
+	contains []string
+	excludes []string
+	// ordered keywords must appear in the serialized bind in this order
+	ordered []string
+}
+
+func TestBindRoundTrip(t *testing.T) {
+	port := int64(443)
+	id := int64(42)
+	maxconn := int64(2000)
+	backlog := int64(4096)
+	mss := int64(1400)
+	mssReduce := int64(-40)
+	tcpUT := int64(30000)
+	tests := []bindRoundTripTest{
+		{
+			name: "accept-proxy",
+			bind: models.Bind{
+				Name:        "proxied",
+				Address:     "0.0.0.0",
+				Port:        &port,
+				AcceptProxy: true,
+			},
+			contains: []string{"accept-proxy"},
+		},
+		{
+			name: "interface with transparent",
+			bind: models.Bind{
+				Name:        "nic",
+				Address:     "10.0.0.1",
+				Port:        &port,
+				Interface:   "eth1",
+				Transparent: true,
+			},
+			contains: []string{"interface eth1", "transparent"},
+		},
+		{
+			name: "id",
+			bind: models.Bind{
+				Name:    "stats",
+				Address: "10.0.0.1",
+				Port:    &port,
+				ID:      &id,
+			},
+			contains: []string{"id 42"},
+		},
+		{
+			name: "v4v6",
+			bind: models.Bind{
+				Name:    "dualstack",
+				Address: "::",
+				Port:    &port,
+				V4v6:    true,
+			},
+			contains: []string{"v4v6"},
+			excludes: []string{"v6only"},
+		},
+		{
+			name: "v6only",
+			bind: models.Bind{
+				Name:    "ipv6",
+				Address: "::",
+				Port:    &port,
+				V6only:  true,
+			},
+			contains: []string{"v6only"},
+			excludes: []string{"v4v6"},
+		},
+		{
+			name: "maxconn and backlog",
+			bind: models.Bind{
+				Name:    "capped",
+				Address: "10.0.0.1",
+				Port:    &port,
+				Maxconn: &maxconn,
+				Backlog: &backlog,
+			},
+			contains: []string{"maxconn 2000", "backlog 4096"},
+		},
+		{
+			name: "mss with tcp-ut",
+			bind: models.Bind{
+				Name:           "wan",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Mss:            &mss,
+				TCPUserTimeout: &tcpUT,
+			},
+			contains: []string{"mss 1400", "tcp-ut 30000"},
+			ordered:  []string{"mss", "tcp-ut"},
+		},
+		{
+			name: "negative mss",
+			bind: models.Bind{
+				Name:    "wan",
+				Address: "10.0.0.1",
+				Port:    &port,
+				Mss:     &mssReduce,
+			},
+			contains: []string{"mss -40"},
+		},
+		{
+			name: "unix socket ownership",
+			bind: models.Bind{
+				Name:    "stats",
+				Address: "/var/run/haproxy-stats.sock",
+				User:    "haproxy",
+				Group:   "admin",
+				Mode:    "660",
+				UID:     "99",
+				Gid:     99,
+			},
+			contains: []string{"user haproxy", "group admin", "mode 660", "uid 99", "gid 99"},
+		},
+		{
+			name: "strict-sni",
+			bind: models.Bind{
+				Name:           "sni",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/certs",
+				StrictSni:      true,
+			},
+			contains: []string{"ssl", "strict-sni"},
+		},
+		{
+			name: "several addresses",
+			bind: models.Bind{
+				Name:           "multi",
+				Address:        ":80,:443,10.0.0.1:8080",
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+			},
+			contains: []string{"ssl", "crt /etc/haproxy/site.pem"},
+		},
+		{
+			name: "ignore errors",
+			bind: models.Bind{
+				Name:           "migration",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				CaIgnoreErr:    "all",
+				CrtIgnoreErr:   "10,18",
+			},
+			contains: []string{"crt-ignore-err 10,18", "ca-ignore-err all"},
+			ordered:  []string{"crt-ignore-err", "ca-ignore-err"},
+		},
+		{
+			name: "no-ca-names",
+			bind: models.Bind{
+				Name:           "mtls",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				SslCafile:      "/etc/haproxy/clients.pem",
+				Verify:         "required",
+				NoCaNames:      true,
+			},
+			contains: []string{"ca-file /etc/haproxy/clients.pem", "verify required", "no-ca-names"},
+			ordered:  []string{"verify", "no-ca-names"},
+		},
+		{
+			name: "prefer-client-ciphers",
+			bind: models.Bind{
+				Name:                "clientorder",
+				Address:             "10.0.0.1",
+				Port:                &port,
+				Ssl:                 true,
+				SslCertificate:      "/etc/haproxy/site.pem",
+				Ciphers:             "ECDHE-RSA-AES128-GCM-SHA256",
+				PreferClientCiphers: true,
+			},
+			contains: []string{"ciphers ECDHE-RSA-AES128-GCM-SHA256", "prefer-client-ciphers"},
+		},
+		{
+			name: "allow-0rtt",
+			bind: models.Bind{
+				Name:           "early",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				SslMaxVer:      "TLSv1.3",
+				Allow0rtt:      true,
+			},
+			contains: []string{"ssl", "ssl-max-ver TLSv1.3", "allow-0rtt"},
+			ordered:  []string{"ssl", "allow-0rtt"},
+		},
+		{
+			name: "proto with alpn",
+			bind: models.Bind{
+				Name:    "internal",
+				Address: "10.0.0.1",
+				Port:    &port,
+				Alpn:    "h2,http/1.1",
+				Proto:   "h2",
+			},
+			contains: []string{"alpn h2,http/1.1", "proto h2"},
+			ordered:  []string{"alpn", "proto"},
+		},
+		{
+			name: "tfo",
+			bind: models.Bind{
+				Name:    "fastopen",
+				Address: "10.0.0.1",
+				Port:    &port,
+				Tfo:     true,
+			},
+			contains: []string{"tfo"},
+			excludes: []string{"defer-accept"},
+		},
+		{
+			name: "defer-accept",
+			bind: models.Bind{
+				Name:        "deferred",
+				Address:     "10.0.0.1",
+				Port:        &port,
+				DeferAccept: true,
+			},
+			contains: []string{"defer-accept"},
+			excludes: []string{"tfo"},
+		},
+		{
+			name: "tfo with defer-accept",
+			bind: models.Bind{
+				Name:        "edge",
+				Address:     "10.0.0.1",
+				Port:        &port,
+				Tfo:         true,
+				DeferAccept: true,
+			},
+			contains: []string{"tfo", "defer-accept"},
+			ordered:  []string{"tfo", "defer-accept"},
+		},
+		{
+			name: "curves and ecdhe with ciphers",
+			bind: models.Bind{
+				Name:           "hardened",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				Ciphers:        "ECDHE-ECDSA-AES256-GCM-SHA384",
+				Curves:         "secp384r1",
+				Ecdhe:          "prime256v1",
+			},
+			contains: []string{"ciphers ECDHE-ECDSA-AES256-GCM-SHA384", "curves secp384r1", "ecdhe prime256v1"},
+			ordered:  []string{"ciphers", "curves", "ecdhe"},
+		},
+		{
+			name: "generate-certificates",
+			bind: models.Bind{
+				Name:                 "generated",
+				Address:              "10.0.0.1",
+				Port:                 &port,
+				Ssl:                  true,
+				SslCertificate:       "/etc/haproxy/default.pem",
+				CaSignFile:           "/etc/haproxy/ca-sign.pem",
+				GenerateCertificates: true,
+			},
+			contains: []string{"ssl", "ca-sign-file /etc/haproxy/ca-sign.pem", "generate-certificates"},
+		},
+		{
+			name: "crl-file with client verification",
+			bind: models.Bind{
+				Name:           "mtls",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				SslCafile:      "/etc/haproxy/ca.pem",
+				CrlFile:        "/etc/haproxy/crl.pem",
+				Verify:         "required",
+			},
+			contains: []string{"ca-file /etc/haproxy/ca.pem", "crl-file /etc/haproxy/crl.pem", "verify required"},
+		},
+		{
+			name: "npn with alpn",
+			bind: models.Bind{
+				Name:           "legacy",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				Alpn:           "h2,http/1.1",
+				Npn:            "http/1.1",
+			},
+			contains: []string{"alpn h2,http/1.1", "npn http/1.1"},
+			ordered:  []string{"alpn", "npn"},
+		},
+		{
+			name: "namespace with transparent",
+			bind: models.Bind{
+				Name:        "public",
+				Address:     "10.0.0.1",
+				Port:        &port,
+				Namespace:   "public",
+				Transparent: true,
+			},
+			contains: []string{"namespace public", "transparent"},
+		},
+		{
+			name: "severity-output on unix socket",
+			bind: models.Bind{
+				Name:           "admin",
+				Address:        "/var/run/haproxy-admin.sock",
+				SeverityOutput: "number",
+			},
+			contains: []string{"severity-output number"},
+		},
+		{
+			name: "level admin",
+			bind: models.Bind{
+				Name:    "runtime-api",
+				Address: "/var/run/haproxy-runtime-api.sock",
+				Level:   "admin",
+			},
+			contains: []string{"level admin"},
+		},
+		{
+			name: "empty interface",
+			bind: models.Bind{
+				Name:    "any",
+				Address: "10.0.0.1",
+				Port:    &port,
+			},
+			excludes: []string{"interface"},
+		},
+	}
+
+	noProtocols := map[string]func(*models.Bind){
+		"no-sslv3":  func(b *models.Bind) { b.NoSslv3 = true },
+		"no-tlsv10": func(b *models.Bind) { b.NoTlsv10 = true },
+		"no-tlsv11": func(b *models.Bind) { b.NoTlsv11 = true },
+		"no-tlsv12": func(b *models.Bind) { b.NoTlsv12 = true },
+		"no-tlsv13": func(b *models.Bind) { b.NoTlsv13 = true },
+	}
+	for keyword, set := range noProtocols {
+		b := models.Bind{
+			Name:           keyword,
+			Address:        "10.0.0.1",
+			Port:           &port,
+			Ssl:            true,
+			SslCertificate: "/etc/haproxy/site.pem",
+		}
+		set(&b)
+		tests = append(tests, bindRoundTripTest{
+			name:     keyword,
+			bind:     b,
+			contains: []string{keyword},
+			excludes: []string{"ssl-min-ver"},
+		})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, line := bindRoundTrip(tt.bind)
+			for _, c := range tt.contains {
+				if !strings.Contains(line, c) {
+					t.Errorf("serialized bind %q does not contain %q", line, c)
+				}
+			}
+			for _, e := range tt.excludes {
+				if strings.Contains(line, e) {
+					t.Errorf("serialized bind %q should not contain %q", line, e)
+				}
+			}
+			last := -1
+			for _, o := range tt.ordered {
+				i := strings.Index(line, o)
+				if i < last {
+					t.Errorf("serialized bind %q has %q out of order %v", line, o, tt.ordered)
+				}
+				last = i
+			}
+			if !reflect.DeepEqual(parsed, &tt.bind) {
+				t.Errorf("parsed bind %+v not equal to given bind %+v", parsed, tt.bind)
+			}
+		})
+	}
+}
+
+func TestCreateBinds(t *testing.T) {
+	port1 := int64(9001)
+	port2 := int64(9002)
+	binds := models.Binds{
+		&models.Bind{Name: "batch1", Address: "192.168.2.1", Port: &port1},
+		&models.Bind{Name: "batch2", Address: "192.168.2.1", Port: &port2},
+	}
+
+	err := client.CreateBinds("test", binds, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetBinds("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(created) != 4 {
+		t.Errorf("%v binds returned, expected 4", len(created))
+	}
+
+	// one bind already exists, none must be created
+	port3 := int64(9003)
+	binds = models.Binds{
+		&models.Bind{Name: "batch3", Address: "192.168.2.1", Port: &port3},
+		&models.Bind{Name: "batch1", Address: "192.168.2.1", Port: &port1},
+	}
+	err = client.CreateBinds("test", binds, "", version)
+	if err == nil {
+		version++
+		t.Error("Should throw error bind already exists")
+	}
+	_, _, err = client.GetBind("batch3", "test", "")
+	if err == nil {
+		t.Error("Bind batch3 should not be created")
+	}
+
+	for _, name := range []string{"batch1", "batch2"} {
+		err = client.DeleteBind(name, "test", "", version)
+		if err != nil {
+			t.Error(err.Error())
+		} else {
+			version++
+		}
+	}
+}
+
+func TestCreateBindEnsureFrontend(t *testing.T) {
+	path := "/tmp/haproxy-bind-ensure.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// existing frontend, only the bind is added
+	_, before, err := c.GetFrontend("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.CreateBindEnsureFrontend("test", &models.Bind{Name: "ensured", Address: "127.0.0.1", Port: misc.Int64P(9001)}, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+	if _, _, err := c.GetBind("ensured", "test", ""); err != nil {
+		t.Error(err.Error())
+	}
+	if _, after, _ := c.GetFrontend("test", ""); !reflect.DeepEqual(after, before) {
+		t.Errorf("Frontend changed to %v, expected %v", after, before)
+	}
+	if err := c.CreateBindEnsureFrontend("test", &models.Bind{Name: "ensured", Address: "127.0.0.1", Port: misc.Int64P(9002)}, "", v); err == nil {
+		t.Error("Should throw error, bind already exists")
+	}
+
+	// missing frontend, created with only its name
+	if err := c.CreateBindEnsureFrontend("ensured", &models.Bind{Name: "first", Address: "127.0.0.1", Port: misc.Int64P(9003)}, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+	_, frontend, err := c.GetFrontend("ensured", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(frontend, &models.Frontend{Name: "ensured"}) {
+		t.Errorf("Frontend created as %v, expected only its name", frontend)
+	}
+	if _, binds, _ := c.GetBinds("ensured", ""); len(binds) != 1 || binds[0].Name != "first" {
+		t.Errorf("Frontend created with binds %v, expected first", binds)
+	}
+
+	// invalid bind in a transaction, the frontend is not created
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.CreateBindEnsureFrontend("invalid", &models.Bind{Name: "bad", Address: "127.0.0.1", Port: misc.Int64P(70000)}, tr.ID, 0); err == nil {
+		t.Error("Should throw error, port out of range")
+	}
+	if _, _, err := c.GetFrontend("invalid", tr.ID); err == nil {
+		t.Error("Frontend created for a bind that failed")
+	}
+	if err := c.DeleteTransaction(tr.ID); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// failed save, neither the frontend nor the bind are committed
+	c.ValidateConfigurationFile = true
+	c.ValidateCmd = "false"
+	if err := c.CreateBindEnsureFrontend("rejected", &models.Bind{Name: "rejected", Address: "127.0.0.1", Port: misc.Int64P(9004)}, "", v); err == nil {
+		t.Error("Should throw error, configuration check failed")
+	}
+	if _, _, err := c.GetFrontend("rejected", ""); err == nil {
+		t.Error("Frontend committed although the configuration check failed")
+	}
+	if cv, _ := c.GetVersion(""); cv != v {
+		t.Errorf("Version is %v after the failed change, expected %v", cv, v)
+	}
+}
+
+const mixedBindsConfig = `# _version=1
+frontend mixed
+  mode http
+  bind :80 name plain1
+  bind :443 name tls1 ssl crt /etc/haproxy/one.pem
+  bind :8080 name plain2 accept-proxy
+  bind :8081 name plain3
+  bind :8443 name tls2 ssl crt /etc/haproxy/two.pem alpn h2
+  bind :9080 name plain4
+`
+
+func TestDeleteBindsWhere(t *testing.T) {
+	path := "/tmp/haproxy-bind-delete-where.cfg"
+	if err := prepareTestFile(mixedBindsConfig, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	notTLS := func(b *models.Bind) bool { return !b.Ssl }
+	deleted, err := c.DeleteBindsWhere("mixed", notTLS, "", 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if deleted != 4 {
+		t.Errorf("%v binds deleted, expected 4", deleted)
+	}
+
+	_, raw, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := "  bind :443 name tls1 ssl crt /etc/haproxy/one.pem\n  bind :8443 name tls2 ssl crt /etc/haproxy/two.pem alpn h2\n"
+	if !strings.Contains(raw, expected) || strings.Count(raw, "bind ") != 2 {
+		t.Errorf("Binds left in configuration:\n%s\nexpected:\n%s", raw, expected)
+	}
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if deleted, err := c.DeleteBindsWhere("mixed", notTLS, "", v); err != nil || deleted != 0 {
+		t.Errorf("%v binds deleted on second run, expected none: %v", deleted, err)
+	}
+	if _, err := c.DeleteBindsWhere("doesnotexist", notTLS, "", v+1); err == nil {
+		t.Error("Should throw error, frontend does not exist")
+	}
+}
+
+func TestCreateBindAt(t *testing.T) {
+	port := int64(9010)
+	l := &models.Bind{Name: "first", Address: "192.168.2.1", Port: &port}
+
+	err := client.CreateBindAt("test", 0, l, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, binds, err := client.GetBinds("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(binds) != 3 || binds[0].Name != "first" {
+		t.Errorf("Bind first not inserted at index 0: %v", binds)
+	}
+
+	port = int64(9011)
+	err = client.CreateBindAt("test", 10, &models.Bind{Name: "outofrange", Address: "192.168.2.1", Port: &port}, "", version)
+	if err == nil {
+		version++
+		t.Error("Should throw error index out of range")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectIndexOutOfRange {
+		t.Errorf("Expected index out of range error, got: %v", err)
+	}
+
+	err = client.DeleteBind("first", "test", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestReplaceBinds(t *testing.T) {
+	err := client.CreateFrontend(&models.Frontend{Name: "replace_binds"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	port1 := int64(80)
+	port2 := int64(443)
+	port3 := int64(8080)
+	err = client.CreateBinds("replace_binds", models.Binds{
+		&models.Bind{Name: "http", Address: "10.0.0.1", Port: &port1},
+		&models.Bind{Name: "https", Address: "10.0.0.1", Port: &port2, Ssl: true, SslCertificate: "/etc/haproxy/site.pem"},
+		&models.Bind{Name: "old", Address: "10.0.0.1", Port: &port3},
+	}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	port4 := int64(8443)
+	desired := models.Binds{
+		&models.Bind{Name: "https", Address: "10.0.0.1", Port: &port2, Ssl: true, SslCertificate: "/etc/haproxy/site.pem"},
+		&models.Bind{Name: "http", Address: "10.0.0.2", Port: &port1},
+		&models.Bind{Name: "new", Address: "10.0.0.1", Port: &port4},
+	}
+	err = client.ReplaceBinds("replace_binds", desired, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, binds, err := client.GetBinds("replace_binds", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(binds, desired) {
+		t.Errorf("Replaced binds %v not equal to desired binds %v", binds, desired)
+	}
+
+	err = client.ReplaceBinds("replace_binds", models.Binds{
+		&models.Bind{Name: "http", Address: "10.0.0.1", Port: &port1},
+		&models.Bind{Name: "http", Address: "10.0.0.2", Port: &port1},
+	}, "", version)
+	if err == nil {
+		version++
+		t.Error("Should throw error, duplicate bind names")
+	}
+
+	err = client.DeleteFrontend("replace_binds", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestRenameBind(t *testing.T) {
+	path := "/tmp/haproxy-bind-rename.cfg"
+	if err := prepareTestFile(mixedBindsConfig, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// written once as the client writes it so only the rename differs below
+	if err := c.CanonicalizeConfiguration("", 1); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, before, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := c.RenameBind("tls1", "public-tls", "mixed", "", 2); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, after, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := strings.Replace(before, "bind :443 name tls1 ssl", "bind :443 name public-tls ssl", 1)
+	if after != expected {
+		t.Errorf("Configuration after rename:\n%s\nexpected:\n%s", after, expected)
+	}
+	_, binds, err := c.GetBinds("mixed", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(binds) != 6 || binds[1].Name != "public-tls" || binds[1].SslCertificate != "/etc/haproxy/one.pem" {
+		t.Errorf("Renamed bind moved or changed: %v", binds)
+	}
+
+	if err := c.RenameBind("plain1", "plain2", "mixed", "", 3); err == nil {
+		t.Error("Should throw error, bind plain2 already exists")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectAlreadyExists {
+		t.Errorf("Expected object already exists error, got: %v", err)
+	}
+	if err := c.RenameBind("tls1", "other", "mixed", "", 3); err == nil {
+		t.Error("Should throw error, bind tls1 does not exist anymore")
+	}
+	if err := c.RenameBind("plain1", "other", "doesnotexist", "", 3); err == nil {
+		t.Error("Should throw error, frontend does not exist")
+	}
+}
+
+func TestRenameBindOptions(t *testing.T) {
+	options := params.ParseBindOptions([]string{"accept-proxy", "name", "old", "maxconn", "10"})
+	renamed := renameBindOptions(options, "new")
+	if got := params.BindOptionsString(renamed); got != "accept-proxy name new maxconn 10" {
+		t.Errorf("Options renamed to %s", got)
+	}
+	if got := params.BindOptionsString(options); got != "accept-proxy name old maxconn 10" {
+		t.Errorf("Original options changed to %s", got)
+	}
+
+	unnamed := params.ParseBindOptions([]string{"ssl", "crt", "/etc/haproxy/site.pem"})
+	if got := params.BindOptionsString(renameBindOptions(unnamed, "new")); got != "name new ssl crt /etc/haproxy/site.pem" {
+		t.Errorf("Options renamed to %s", got)
+	}
+}
+
+func TestCloneBind(t *testing.T) {
+	for _, name := range []string{"clone_src", "clone_dst"} {
+		if err := client.CreateFrontend(&models.Frontend{Name: name}, "", version); err != nil {
+			t.Fatal(err.Error())
+		}
+		version++
+	}
+
+	port := int64(443)
+	src := &models.Bind{
+		Name:           "https",
+		Address:        "10.0.0.1",
+		Port:           &port,
+		Ssl:            true,
+		SslCertificate: "/etc/haproxy/site.pem",
+		Alpn:           "h2,http/1.1",
+	}
+	if err := client.CreateBind("clone_src", src, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	if err := client.CloneBind("https", "clone_src", "clone_dst", "", "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+	if err := client.CloneBind("https", "clone_src", "clone_dst", "https_copy", "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, binds, err := client.GetBinds("clone_dst", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(binds) != 2 {
+		t.Fatalf("%v binds returned, expected 2", len(binds))
+	}
+	for i, name := range []string{"https", "https_copy"} {
+		expected := *src
+		expected.Name = name
+		if !BindsEqual(binds[i], &expected) {
+			t.Errorf("Cloned bind %s differs in %v", name, DiffBinds(binds[i], &expected))
+		}
+	}
+
+	err = client.CloneBind("https", "clone_src", "clone_dst", "", "", version)
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectAlreadyExists {
+		t.Errorf("Expected ErrObjectAlreadyExists, got %v", err)
+	}
+	err = client.CloneBind("doesnotexist", "clone_src", "clone_dst", "", "", version)
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectDoesNotExist {
+		t.Errorf("Expected ErrObjectDoesNotExist, got %v", err)
+	}
+	err = client.CloneBind("https", "clone_src", "doesnotexist", "", "", version)
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected ErrParentDoesNotExist, got %v", err)
+	}
+
+	for _, name := range []string{"clone_src", "clone_dst"} {
+		if err := client.DeleteFrontend(name, "", version); err != nil {
+			t.Error(err.Error())
+		} else {
+			version++
+		}
+	}
+}
+
+func TestEditBindCrtToCrtList(t *testing.T) {
+	port := int64(8443)
+	l := &models.Bind{
+		Name:           "certs",
+		Address:        "192.168.2.1",
+		Port:           &port,
+		Ssl:            true,
+		SslCertificate: "/etc/haproxy/site.pem",
+	}
+
+	err := client.CreateBind("test", l, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	l.SslCertificate = ""
+	l.CrtList = "/etc/haproxy/certs.list"
+	err = client.EditBind("certs", "test", l, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, bind, err := client.GetBind("certs", "test", "")
+	if err != nil {
+		t.Error(err.Error())
+	} else if !reflect.DeepEqual(bind, l) {
+		fmt.Printf("Edited bind: %v\n", bind)
+		fmt.Printf("Given bind: %v\n", l)
+		t.Error("Edited bind not equal to given bind")
+	}
+
+	err = client.DeleteBind("certs", "test", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestEditBindKeepsTLSTicketKeys(t *testing.T) {
+	port := int64(8444)
+	maxconn := int64(100)
+	l := &models.Bind{
+		Name:           "tickets",
+		Address:        "192.168.2.1",
+		Port:           &port,
+		Ssl:            true,
+		SslCertificate: "/etc/haproxy/site.pem",
+		TLSTicketKeys:  "/etc/haproxy/tickets.key",
+		Maxconn:        &maxconn,
+	}
+
+	err := client.CreateBind("test", l, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, bind, err := client.GetBind("tickets", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	maxconn = int64(200)
+	bind.Maxconn = &maxconn
+	err = client.EditBind("tickets", "test", bind, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, bind, err = client.GetBind("tickets", "test", "")
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		if bind.TLSTicketKeys != "/etc/haproxy/tickets.key" {
+			t.Errorf("tls-ticket-keys not preserved: %v", bind.TLSTicketKeys)
+		}
+		if bind.Maxconn == nil || *bind.Maxconn != 200 {
+			t.Errorf("maxconn not edited: %v", bind.Maxconn)
+		}
+	}
+
+	err = client.DeleteBind("tickets", "test", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+const bindCommentsConfig = `# _version=1
+frontend comments
+  mode http
+  # public listeners
+  bind :80 name http # plain http, redirected
+  bind :443 name https ssl crt /etc/haproxy/site.pem
+  default_backend comments
+
+backend comments
+  mode http
+  server web1 10.0.0.1:80 check # rack 12
+`
+
+func TestEditBindKeepsComment(t *testing.T) {
+	path := "/tmp/haproxy-bind-comments.cfg"
+	if err := prepareTestFile(bindCommentsConfig, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	v, bind, err := c.GetBind("http", "comments", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if bind.Comment != "plain http, redirected" {
+		t.Errorf("Comment is %q, expected %q", bind.Comment, "plain http, redirected")
+	}
+	maxconn := int64(500)
+	bind.Maxconn = &maxconn
+	if err := c.EditBind("http", "comments", bind, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+
+	_, https, err := c.GetBind("https", "comments", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	https.Comment = "tls termination"
+	if err := c.EditBind("https", "comments", https, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+
+	_, server, err := c.GetServer("web1", "comments", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if server.Comment != "rack 12" {
+		t.Errorf("Comment is %q, expected %q", server.Comment, "rack 12")
+	}
+	server.Weight = misc.Int64P(10)
+	if err := c.EditServer("web1", "comments", server, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, raw, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, line := range []string{
+		"  # public listeners\n  bind :80 name http maxconn 500 # plain http, redirected\n",
+		"  bind :443 name https ssl crt /etc/haproxy/site.pem # tls termination\n",
+		"  server web1 10.0.0.1:80 check weight 10 # rack 12\n",
+	} {
+		if !strings.Contains(raw, line) {
+			t.Errorf("Configuration does not contain %q:\n%s", line, raw)
+		}
+	}
+
+	bind.Comment = "two\nlines"
+	if err := c.EditBind("http", "comments", bind, "", 0); err == nil {
+		t.Error("Should throw error, comment spans two lines")
+	}
+}
+
+// goldenBinds are bind lines holding options in canonical order, parsing and
+// serializing them must not change them (no-tls-tickets is left out as the
+// parser does not know it as a bind option)
+const goldenBinds = `bind 10.0.0.1:443 name golden id 1 process 1/1 interface eth0 namespace public transparent v4v6 tfo defer-accept backlog 1024 maxconn 2000 mss 1400 tcp-ut 30000 nice 10 accept-proxy accept-netscaler-cip 1234 expose-fd listeners ssl crt /etc/haproxy/site.pem crt-ignore-err all ca-file /etc/haproxy/ca.pem ca-verify-file /etc/haproxy/ca-verify.pem ca-ignore-err 10,18 ca-sign-file /etc/haproxy/ca-sign.pem ca-sign-pass secret crl-file /etc/haproxy/crl.pem verify required ssl-min-ver TLSv1.2 ssl-max-ver TLSv1.3 force-tlsv12 no-sslv3 no-tlsv10 no-tlsv11 ciphers ECDHE-RSA-AES128-GCM-SHA256 ciphersuites TLS_AES_128_GCM_SHA256 curves secp384r1 ecdhe prime256v1 alpn h2,http/1.1 npn http/1.1 strict-sni generate-certificates no-ca-names tls-ticket-keys /etc/haproxy/tickets.key prefer-client-ciphers allow-0rtt proto h2
+bind /var/run/haproxy.sock name socket user haproxy uid 99 group admin gid 99 mode 660 level admin severity-output number
+bind 10.0.0.2:80-81 name range v6only crt-list /etc/haproxy/certs.list
+bind :80,:443,10.0.0.1:8080 name multi ssl crt /etc/haproxy/site.pem
+bind :::80-81 name ipv6range v6only
+bind 2001:db8::1:443 name ipv6 ssl crt /etc/haproxy/site.pem`
+
+func TestSerializeBindGolden(t *testing.T) {
+	for _, golden := range strings.Split(goldenBinds, "\n") {
+		parts := strings.Fields(golden)
+		b := ParseBind(types.Bind{
+			Path:   parts[1],
+			Params: params.ParseBindOptions(parts[2:]),
+		})
+		ondisk := SerializeBind(*b)
+		line := "bind " + ondisk.Path + " " + params.BindOptionsString(ondisk.Params)
+		if line != golden {
+			t.Errorf("serialized bind differs from golden\n got: %s\nwant: %s", line, golden)
+		}
+	}
+}
+
+func TestParseBindAddress(t *testing.T) {
+	tests := []struct {
+		path         string
+		address      string
+		port         int64
+		portRangeEnd int64
+	}{
+		{path: "10.0.0.1:80", address: "10.0.0.1", port: 80},
+		{path: "*:80-81", address: "*", port: 80, portRangeEnd: 81},
+		{path: ":::443", address: "::", port: 443},
+		{path: "2001:db8::1:443", address: "2001:db8::1", port: 443},
+		{path: "ipv6@:::8080-8081", address: "ipv6@::", port: 8080, portRangeEnd: 8081},
+		{path: "/var/run/haproxy.sock", address: "/var/run/haproxy.sock"},
+		{path: "unix@/var/run/haproxy.sock", address: "unix@/var/run/haproxy.sock"},
+		{path: "unix@/var/run/haproxy:1.sock", address: "unix@/var/run/haproxy:1.sock"},
+		{path: "fd@3", address: "fd@3"},
+		{path: "fd@${FD_PUBLIC}", address: "fd@${FD_PUBLIC}"},
+		{path: "sockpair@10", address: "sockpair@10"},
+		{path: "abns@haproxy-peers", address: "abns@haproxy-peers"},
+		{path: "abns@haproxy:8080", address: "abns@haproxy:8080"},
+	}
+	for _, tt := range tests {
+		b := ParseBind(types.Bind{Path: tt.path})
+		var port, portRangeEnd int64
+		if b.Port != nil {
+			port = *b.Port
+		}
+		if b.PortRangeEnd != nil {
+			portRangeEnd = *b.PortRangeEnd
+		}
+		if b.Address != tt.address || port != tt.port || portRangeEnd != tt.portRangeEnd {
+			t.Errorf("%s parsed as %s %d-%d, expected %s %d-%d", tt.path, b.Address, port, portRangeEnd, tt.address, tt.port, tt.portRangeEnd)
+		}
+	}
+}
+
+func TestSocketBindAddresses(t *testing.T) {
+	for _, path := range []string{"fd@3", "sockpair@10", "abns@haproxy:8080", "unix@/var/run/haproxy.sock"} {
+		b := ParseBind(types.Bind{Path: path, Params: params.ParseBindOptions([]string{"name", "socket", "accept-proxy"})})
+		if err := ValidateBind(b); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+		if serialized := SerializeBind(*b); serialized.Path != path {
+			t.Errorf("%s serialized as %s", path, serialized.Path)
+		}
+
+		b.Port = misc.Int64P(80)
+		if err := ValidateBind(b); err == nil {
+			t.Errorf("%s: should throw error, socket address with a port", path)
+		}
+	}
+}
+
+func TestSerializeBindSslBeforeStrictSni(t *testing.T) {
+	port := int64(443)
+	_, line := bindRoundTrip(models.Bind{
+		Name:           "sni",
+		Address:        "10.0.0.1",
+		Port:           &port,
+		Ssl:            true,
+		SslCertificate: "/etc/haproxy/certs",
+		StrictSni:      true,
+	})
+	sslIndex := strings.Index(line, " ssl")
+	strictSniIndex := strings.Index(line, "strict-sni")
+	if sslIndex == -1 || strictSniIndex == -1 || sslIndex > strictSniIndex {
+		t.Errorf("expected ssl before strict-sni in %q", line)
+	}
+}
+
+func TestCreateBindInvalid(t *testing.T) {
+	port := int64(443)
+	negative := int64(-1)
+	tests := []struct {
+		name string
+		bind models.Bind
+	}{
+		{
+			name: "negative id",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "10.0.0.1",
+				Port:    &port,
+				ID:      &negative,
+			},
+		},
+		{
+			name: "negative maxconn",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "10.0.0.1",
+				Port:    &port,
+				Maxconn: &negative,
+			},
+		},
+		{
+			name: "negative backlog",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "10.0.0.1",
+				Port:    &port,
+				Backlog: &negative,
+			},
+		},
+		{
+			name: "socket ownership on tcp bind",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "10.0.0.1",
+				Port:    &port,
+				User:    "haproxy",
+				Mode:    "660",
+			},
+		},
+		{
+			name: "strict-sni without ssl",
+			bind: models.Bind{
+				Name:      "invalid",
+				Address:   "10.0.0.1",
+				Port:      &port,
+				StrictSni: true,
+			},
+		},
+		{
+			name: "crt and crt-list",
+			bind: models.Bind{
+				Name:           "invalid",
+				Address:        "10.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				CrtList:        "/etc/haproxy/certs.list",
+			},
+		},
+		{
+			name: "crt path with whitespace",
+			bind: models.Bind{
+				Name:           "invalid",
+				Address:        "127.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/my site.pem",
+			},
+		},
+		{
+			name: "curves without ssl",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "127.0.0.1",
+				Port:    &port,
+				Curves:  "secp384r1",
+			},
+		},
+		{
+			name: "ecdhe without ssl",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "127.0.0.1",
+				Port:    &port,
+				Ecdhe:   "prime256v1",
+			},
+		},
+		{
+			name: "generate-certificates without ssl",
+			bind: models.Bind{
+				Name:                 "invalid",
+				Address:              "127.0.0.1",
+				Port:                 &port,
+				CaSignFile:           "/etc/haproxy/ca-sign.pem",
+				GenerateCertificates: true,
+			},
+		},
+		{
+			name: "generate-certificates without ca",
+			bind: models.Bind{
+				Name:                 "invalid",
+				Address:              "127.0.0.1",
+				Port:                 &port,
+				Ssl:                  true,
+				GenerateCertificates: true,
+			},
+		},
+		{
+			name: "unknown severity-output",
+			bind: models.Bind{
+				Name:           "invalid",
+				Address:        "/var/run/haproxy-admin.sock",
+				SeverityOutput: "verbose",
+			},
+		},
+		{
+			name: "allow-0rtt without ssl",
+			bind: models.Bind{
+				Name:      "invalid",
+				Address:   "127.0.0.1",
+				Port:      &port,
+				Allow0rtt: true,
+			},
+		},
+		{
+			name: "allow-0rtt without TLSv1.3",
+			bind: models.Bind{
+				Name:           "invalid",
+				Address:        "127.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				SslMaxVer:      "TLSv1.2",
+				Allow0rtt:      true,
+			},
+		},
+		{
+			name: "malformed crt-ignore-err",
+			bind: models.Bind{
+				Name:           "invalid",
+				Address:        "127.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				CrtIgnoreErr:   "10, 18",
+			},
+		},
+		{
+			name: "no-ca-names without verify",
+			bind: models.Bind{
+				Name:           "invalid",
+				Address:        "127.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				SslCafile:      "/etc/haproxy/clients.pem",
+				NoCaNames:      true,
+			},
+		},
+		{
+			name: "no-ca-names without ca-file",
+			bind: models.Bind{
+				Name:           "invalid",
+				Address:        "127.0.0.1",
+				Port:           &port,
+				Ssl:            true,
+				SslCertificate: "/etc/haproxy/site.pem",
+				Verify:         "optional",
+				NoCaNames:      true,
+			},
+		},
+		{
+			name: "several addresses with port",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "10.0.0.1:80,10.0.0.2",
+				Port:    &port,
+			},
+		},
+		{
+			name: "prefer-client-ciphers without ssl",
+			bind: models.Bind{
+				Name:                "invalid",
+				Address:             "127.0.0.1",
+				Port:                &port,
+				PreferClientCiphers: true,
+			},
+		},
+		{
+			name: "unknown proto",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "127.0.0.1",
+				Port:    &port,
+				Proto:   "h3",
+			},
+		},
+		{
+			name: "unknown level",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "/var/run/haproxy-runtime-api.sock",
+				Level:   "root",
+			},
+		},
+		{
+			name: "v4v6 and v6only",
+			bind: models.Bind{
+				Name:    "invalid",
+				Address: "::",
+				Port:    &port,
+				V4v6:    true,
+				V6only:  true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.CreateBind("test", &tt.bind, "", version)
+			if err == nil {
+				version++
+				t.Fatal("Should throw validation error")
+			}
+			confErr, ok := err.(*ConfError)
+			if !ok || confErr.Code() != ErrValidationError {
+				t.Errorf("Expected validation error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateBindSkipSemanticValidation(t *testing.T) {
+	client.SkipSemanticValidation = true
+	defer func() { client.SkipSemanticValidation = false }()
+
+	port := int64(8444)
+	b := &models.Bind{
+		Name:      "nossl",
+		Address:   "127.0.0.1",
+		Port:      &port,
+		StrictSni: true,
+	}
+	if err := client.CreateBind("test", b, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	negative := int64(-1)
+	b.Maxconn = &negative
+	if err := client.EditBind("nossl", "test", b, "", version); err == nil {
+		version++
+		t.Error("Schema validation should still run")
+	}
+
+	if err := client.DeleteBind("nossl", "test", "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+}
+
+func TestCreateBindPortRangeEndOnly(t *testing.T) {
+	path := "/tmp/haproxy-bind-range.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	end := int64(8090)
+	b := &models.Bind{Name: "rangeonly", Address: "127.0.0.1", PortRangeEnd: &end}
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.CreateBind("test", b, "", v); err == nil {
+		t.Error("CreateBind should throw error, port range end without port")
+	}
+	if err := c.CreateBindAt("test", 0, b, "", v); err == nil {
+		t.Error("CreateBindAt should throw error, port range end without port")
+	}
+	if err := c.CreateBindEnsureFrontend("new", b, "", v); err == nil {
+		t.Error("CreateBindEnsureFrontend should throw error, port range end without port")
+	}
+
+	// without semantic validation the bind is only checked when inserted
+	c.SkipSemanticValidation = true
+	if err := c.CreateBindAt("test", 0, b, "", v); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestCreateBindValidationSubErrors(t *testing.T) {
+	negative := int64(-1)
+	b := &models.Bind{
+		Name:    "invalid",
+		Address: "127.0.0.1",
+		ID:      &negative,
+		Maxconn: &negative,
+		Backlog: &negative,
+	}
+	err := client.CreateBind("test", b, "", version)
+	if err == nil {
+		version++
+		t.Fatal("Should throw validation error")
+	}
+	confErr, ok := err.(*ConfError)
+	if !ok || confErr.Code() != ErrValidationError {
+		t.Fatalf("Expected validation error, got: %v", err)
+	}
+	if len(confErr.SubErrors()) != 3 {
+		t.Errorf("Expected 3 sub errors, got %d: %v", len(confErr.SubErrors()), confErr.SubErrors())
+	}
+	for _, field := range []string{"id", "maxconn", "backlog"} {
+		if !strings.Contains(confErr.Error(), field) {
+			t.Errorf("Error %q does not mention %s", confErr.Error(), field)
+		}
+	}
+	if strings.Contains(confErr.Error(), "\n") {
+		t.Errorf("Error %q should be a single line", confErr.Error())
+	}
+}
+
+func TestCreateBindGenerateCertificates(t *testing.T) {
+	port := int64(8443)
+	b := &models.Bind{
+		Name:                 "generated",
+		Address:              "127.0.0.1",
+		Port:                 &port,
+		Ssl:                  true,
+		CaSignFile:           "/etc/haproxy/ca-sign.pem",
+		GenerateCertificates: true,
+	}
+	if err := client.CreateBind("test", b, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, bind, err := client.GetBind("generated", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !bind.GenerateCertificates {
+		t.Error("generate-certificates not set")
+	}
+
+	if err := client.DeleteBind("generated", "test", "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+}
+
+func TestDiffBinds(t *testing.T) {
+	port := int64(443)
+	samePort := int64(443)
+	zero := int64(0)
+	tests := []struct {
+		name string
+		a    *models.Bind
+		b    *models.Bind
+		diff []string
+	}{
+		{
+			name: "equal",
+			a:    &models.Bind{Name: "https", Address: "10.0.0.1", Port: &port, Ssl: true},
+			b:    &models.Bind{Name: "https", Address: "10.0.0.1", Port: &samePort, Ssl: true},
+			diff: []string{},
+		},
+		{
+			name: "nil and zero pointer",
+			a:    &models.Bind{Name: "http", Address: "10.0.0.1"},
+			b:    &models.Bind{Name: "http", Address: "10.0.0.1", Maxconn: &zero},
+			diff: []string{"maxconn"},
+		},
+		{
+			name: "zero pointers",
+			a:    &models.Bind{Name: "http", Maxconn: &zero, Backlog: &zero},
+			b:    &models.Bind{Name: "http", Maxconn: &zero, Backlog: &zero},
+			diff: []string{},
+		},
+		{
+			name: "nil and empty bind",
+			a:    nil,
+			b:    &models.Bind{},
+			diff: []string{},
+		},
+		{
+			name: "ignored errors order",
+			a:    &models.Bind{Name: "https", CaIgnoreErr: "10,18", CrtIgnoreErr: "all"},
+			b:    &models.Bind{Name: "https", CaIgnoreErr: "18,10", CrtIgnoreErr: "all"},
+			diff: []string{},
+		},
+		{
+			name: "changed fields",
+			a:    &models.Bind{Name: "https", Address: "10.0.0.1", Port: &port, Ssl: true, Alpn: "h2"},
+			b:    &models.Bind{Name: "https", Address: "10.0.0.1", Alpn: "h2,http/1.1"},
+			diff: []string{"alpn", "port", "ssl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffBinds(tt.a, tt.b)
+			if !reflect.DeepEqual(diff, tt.diff) {
+				t.Errorf("DiffBinds returned %v, expected %v", diff, tt.diff)
+			}
+			if BindsEqual(tt.a, tt.b) != (len(tt.diff) == 0) {
+				t.Errorf("BindsEqual does not match DiffBinds %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseAllBinds(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+frontend first
+  bind 127.0.0.1:80 name http
+  bind 127.0.0.1:443 name https ssl crt /etc/haproxy/site.pem
+
+frontend second
+  bind /var/run/second.sock name socket
+  bind :8080,:8443 name multi ssl crt /etc/haproxy/site.pem
+
+frontend third
+  mode tcp
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	binds, err := ParseAllBinds(p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := map[string][]string{
+		"first":  {"http", "https"},
+		"second": {"socket", "multi"},
+		"third":  {},
+	}
+	if len(binds) != len(expected) {
+		t.Errorf("%v frontends returned, expected %v", len(binds), len(expected))
+	}
+	for frontend, names := range expected {
+		fBinds, ok := binds[frontend]
+		if !ok {
+			t.Errorf("Frontend %s missing", frontend)
+			continue
+		}
+		if len(fBinds) != len(names) {
+			t.Errorf("%v binds returned in frontend %s, expected %v", len(fBinds), frontend, len(names))
+			continue
+		}
+		for i, name := range names {
+			if fBinds[i].Name != name {
+				t.Errorf("Bind %v in frontend %s is %s, expected %s", i, frontend, fBinds[i].Name, name)
+			}
+			if name == "multi" && (fBinds[i].Address != ":8080,:8443" || fBinds[i].Port != nil) {
+				t.Errorf("Bind multi parsed with address %s, expected :8080,:8443 without port", fBinds[i].Address)
+			}
+		}
+	}
+
+	empty := &parser.Parser{}
+	if err := empty.ParseData("global\n  daemon\n"); err != nil {
+		t.Fatal(err.Error())
+	}
+	binds, err = ParseAllBinds(empty)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if binds == nil || len(binds) != 0 {
+		t.Errorf("Expected empty map, got %v", binds)
+	}
+}
+
+func TestGetAllBinds(t *testing.T) {
+	v, binds, err := client.GetAllBinds("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+	_, frontends, err := client.GetFrontends("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(binds) != len(frontends) {
+		t.Errorf("%v frontends returned, expected %v", len(binds), len(frontends))
+	}
+	_, testBinds, _ := client.GetBinds("test", "")
+	if !reflect.DeepEqual(binds["test"], testBinds) {
+		t.Errorf("Binds of frontend test differ from GetBinds")
+	}
+}
+
+func TestBindCtxCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := client.GetBindsCtx(ctx, "test", ""); err != context.Canceled {
+		t.Errorf("GetBindsCtx: expected context.Canceled, got %v", err)
+	}
+
+	port := int64(9443)
+	b := &models.Bind{Name: "cancelled", Address: "127.0.0.1", Port: &port}
+	if err := client.CreateBindCtx(ctx, "test", b, "", version); err != context.Canceled {
+		t.Errorf("CreateBindCtx: expected context.Canceled, got %v", err)
+	}
+	if err := client.DeleteBindCtx(ctx, "webserv", "test", "", version); err != context.Canceled {
+		t.Errorf("DeleteBindCtx: expected context.Canceled, got %v", err)
+	}
+
+	v, err := client.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+	if _, _, err := client.GetBind("cancelled", "test", ""); err == nil {
+		t.Error("Bind cancelled should not have been created")
+	}
+}
+
+func TestBindCtxCancelledTransaction(t *testing.T) {
+	path := "/tmp/haproxy-bind-ctx.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b := &models.Bind{Name: "cancelled", Address: "127.0.0.1", Port: misc.Int64P(9443)}
+	if err := c.CreateBindCtx(ctx, "test", b, tr.ID, 0); err != context.Canceled {
+		t.Errorf("CreateBindCtx: expected context.Canceled, got %v", err)
+	}
+	if err := c.CreateBind("test", b, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	b.Maxconn = misc.Int64P(10)
+	if err := c.EditBindCtx(ctx, "cancelled", "test", b, tr.ID, 0); err != context.Canceled {
+		t.Errorf("EditBindCtx: expected context.Canceled, got %v", err)
+	}
+	if err := c.DeleteBindCtx(ctx, "cancelled", "test", tr.ID, 0); err != context.Canceled {
+		t.Errorf("DeleteBindCtx: expected context.Canceled, got %v", err)
+	}
+
+	// none of the cancelled changes is in the transaction
+	if _, err := c.CommitTransaction(tr.ID); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, bind, err := c.GetBind("cancelled", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if bind.Maxconn != nil {
+		t.Errorf("Cancelled edit committed, maxconn %v", *bind.Maxconn)
+	}
+}
+
+func TestWalkBinds(t *testing.T) {
+	v, all, err := client.GetAllBinds("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	walked := map[string]models.Binds{}
+	wv, err := client.WalkBinds(context.Background(), "", func(frontend string, bind *models.Bind) error {
+		walked[frontend] = append(walked[frontend], bind)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if wv != v {
+		t.Errorf("Version %v returned, expected %v", wv, v)
+	}
+	for frontend, binds := range all {
+		if len(binds) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(walked[frontend], binds) {
+			t.Errorf("Binds of frontend %s walked as %v, expected %v", frontend, walked[frontend], binds)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	if _, err := client.WalkBinds(context.Background(), "", func(string, *models.Bind) error {
+		calls++
+		return stop
+	}); err != stop || calls != 1 {
+		t.Errorf("Walk returned %v after %v calls, expected to stop after the first", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	if _, err := client.WalkBinds(ctx, "", func(string, *models.Bind) error {
+		calls++
+		cancel()
+		return nil
+	}); err != context.Canceled || calls != 1 {
+		t.Errorf("Walk returned %v after %v calls, expected context.Canceled after the first", err, calls)
+	}
+}
+
+func prepareBenchmarkBinds(b *testing.B, path string, frontends, binds int) *Client {
+	b.Helper()
+	var sb strings.Builder
+	sb.WriteString("# _version=1\n")
+	for f := 0; f < frontends; f++ {
+		sb.WriteString(fmt.Sprintf("frontend tenant%d\n  mode http\n", f))
+		for i := 0; i < binds; i++ {
+			sb.WriteString(fmt.Sprintf("  bind 10.%d.%d.%d:443 name bind%d ssl crt /etc/haproxy/tenant%d.pem alpn h2,http/1.1 maxconn 1000\n", f/256, f%256, i, i, f))
+		}
+	}
+	if err := prepareTestFile(sb.String(), path); err != nil {
+		b.Fatal(err)
+	}
+	c, err := prepareClient(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return c
+}
+
+// liveHeap returns the heap memory still in use after read returns, with the
+// value returned by read kept alive. The heap is collected twice before each
+// reading as sync.Pool objects survive one collection.
+func liveHeap(read func() interface{}) float64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	res := read()
+	runtime.GC()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(res)
+	if after.HeapAlloc < before.HeapAlloc {
+		return 0
+	}
+	return float64(after.HeapAlloc - before.HeapAlloc)
+}
+
+// BenchmarkGetAllBinds and BenchmarkWalkBinds read the same 10000 binds, their
+// live-B/op metric is the memory held once all binds are read
+func BenchmarkGetAllBinds(b *testing.B) {
+	path := "/tmp/haproxy-bench-all-binds.cfg"
+	c := prepareBenchmarkBinds(b, path, 1000, 10)
+	defer func() { _ = deleteTestFile(path) }()
+
+	// measured before the loop, ResetTimer deletes reported metrics
+	live := liveHeap(func() interface{} {
+		_, binds, _ := c.GetAllBinds("")
+		return binds
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, binds, err := c.GetAllBinds("")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(binds) != 1000 {
+			b.Fatalf("%v frontends returned, expected 1000", len(binds))
+		}
+	}
+	b.ReportMetric(live, "live-B/op")
+}
+
+func BenchmarkWalkBinds(b *testing.B) {
+	path := "/tmp/haproxy-bench-walk-binds.cfg"
+	c := prepareBenchmarkBinds(b, path, 1000, 10)
+	defer func() { _ = deleteTestFile(path) }()
+
+	// measured before the loop, ResetTimer deletes reported metrics
+	live := liveHeap(func() interface{} {
+		count := 0
+		_, _ = c.WalkBinds(context.Background(), "", func(string, *models.Bind) error {
+			count++
+			return nil
+		})
+		return count
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		_, err := c.WalkBinds(context.Background(), "", func(string, *models.Bind) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count != 10000 {
+			b.Fatalf("%v binds walked, expected 10000", count)
+		}
+	}
+	b.ReportMetric(live, "live-B/op")
+}
+
+func BenchmarkGetBindByName(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("frontend bench\n  mode http\n")
+	for i := 0; i < 500; i++ {
+		sb.WriteString(fmt.Sprintf("  bind 10.0.%d.%d:443 name bind%d ssl crt-list /etc/haproxy/certs%d.list alpn h2,http/1.1 maxconn 1000\n", i/256, i%256, i, i))
+	}
+	p := &parser.Parser{}
+	if err := p.ParseData(sb.String()); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if bind, _ := GetBindByName("bind499", "bench", p); bind == nil {
+			b.Fatal("bind499 not found")
+		}
+	}
+}
+
+func TestValidateBind(t *testing.T) {
+	port := int64(443)
+	negative := int64(-1)
+	rangeEnd := int64(400)
+	valid := &models.Bind{
+		Name:           "https",
+		Address:        "127.0.0.1",
+		Port:           &port,
+		Ssl:            true,
+		SslCertificate: "/etc/haproxy/site.pem",
+	}
+	if err := ValidateBind(valid); err != nil {
+		t.Errorf("Valid bind returned error: %v", err)
+	}
+
+	for name, b := range map[string]*models.Bind{
+		"schema":     {Name: "https", Address: "127.0.0.1", Port: &port, Maxconn: &negative},
+		"semantic":   {Name: "https", Address: "127.0.0.1", Port: &port, StrictSni: true},
+		"port range": {Name: "https", Address: "127.0.0.1", Port: &port, PortRangeEnd: &rangeEnd},
+	} {
+		err := ValidateBind(b)
+		if err == nil {
+			t.Errorf("Invalid %s bind returned no error", name)
+			continue
+		}
+		if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+			t.Errorf("Expected validation error for invalid %s bind, got: %v", name, err)
+		}
+	}
+
+	if err := ValidateServer(&models.Server{Name: "s1", Address: "10.0.0.1", SendProxy: "enabled", SendProxyV2: "enabled"}); err == nil {
+		t.Error("Invalid server returned no error")
+	}
+	if err := ValidateFilter(&models.Filter{Type: "spoe"}); err == nil {
+		t.Error("Invalid filter returned no error")
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	path := "/tmp/haproxy-register-validator.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	var validated []string
+	c.RegisterValidator(func(data interface{}) error {
+		if b, ok := data.(*models.Bind); ok {
+			validated = append(validated, b.Name)
+			if !b.Ssl {
+				return errors.New("binds must use ssl")
+			}
+		}
+		return nil
+	})
+
+	port := int64(8443)
+	plain := &models.Bind{Name: "plain", Address: "127.0.0.1", Port: &port}
+	v, _ := c.GetVersion("")
+	err := c.CreateBind("test", plain, "", v)
+	if err == nil {
+		t.Fatal("Should throw validation error, bind without ssl")
+	}
+	confErr, ok := err.(*ConfError)
+	if !ok || confErr.Code() != ErrValidationError || !strings.Contains(confErr.Error(), "binds must use ssl") {
+		t.Errorf("Expected validation error from the validator, got: %v", err)
+	}
+
+	// the schema is validated first, an invalid bind never reaches the validator
+	negative := int64(-1)
+	validated = nil
+	if err := c.CreateBind("test", &models.Bind{Name: "invalid", Address: "127.0.0.1", Maxconn: &negative}, "", v); err == nil {
+		t.Fatal("Should throw validation error, negative maxconn")
+	}
+	if len(validated) != 0 {
+		t.Errorf("Validator ran on a bind failing schema validation: %v", validated)
+	}
+
+	tls := &models.Bind{Name: "tls", Address: "127.0.0.1", Port: &port, Ssl: true, SslCertificate: "/etc/haproxy/site.pem"}
+	if err := c.CreateBind("test", tls, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+
+	// batch methods validate every bind
+	if err := c.CreateBinds("test", models.Binds{plain}, "", v); err == nil {
+		t.Error("Should throw validation error, bind without ssl in a batch")
+	}
+
+	// other objects are left to the validator
+	if err := c.CreateServer("test", &models.Server{Name: "validated", Address: "10.0.0.9"}, "", v); err != nil {
+		t.Error(err.Error())
 	}
 }
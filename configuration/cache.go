@@ -0,0 +1,215 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"fmt"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetCaches returns configuration version and an array of
+// configured caches. Returns error on fail.
+func (c *Client) GetCaches(transactionID string) (int64, models.Caches, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	names, err := p.SectionsGet(parser.Cache)
+	if err != nil {
+		return v, nil, err
+	}
+
+	caches := models.Caches{}
+	for _, name := range names {
+		cache := &models.Cache{Name: name}
+		if err := ParseCacheSection(p, cache); err != nil {
+			return v, nil, err
+		}
+		caches = append(caches, cache)
+	}
+
+	return v, caches, nil
+}
+
+// GetCache returns configuration version and a requested cache.
+// Returns error on fail or if cache does not exist.
+func (c *Client) GetCache(name string, transactionID string) (int64, *models.Cache, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Cache, name, p) {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Cache %s does not exist", name))
+	}
+
+	cache := &models.Cache{Name: name}
+	if err := ParseCacheSection(p, cache); err != nil {
+		return v, nil, err
+	}
+
+	return v, cache, nil
+}
+
+// DeleteCache deletes a cache in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteCache(name string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Cache, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Cache, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsDelete(parser.Cache, name); err != nil {
+		return c.HandleError(name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditCache edits a cache in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditCache(name string, data *models.Cache, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Cache, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Cache, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := SerializeCacheSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateCache creates a cache in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateCache(data *models.Cache, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if c.checkSectionExists(parser.Cache, data.Name, p) {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("%s %s already exists", parser.Cache, data.Name))
+		return c.HandleError(data.Name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsCreate(parser.Cache, data.Name); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := SerializeCacheSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ParseCacheSection(p *parser.Parser, cache *models.Cache) error {
+	for attribute, field := range cacheNumbers(cache) {
+		data, err := p.Get(parser.Cache, cache.Name, attribute, false)
+		if err != nil {
+			continue
+		}
+		if d, ok := data.(*types.Int64C); ok && d != nil {
+			value := d.Value
+			*field = &value
+		}
+	}
+	return nil
+}
+
+func SerializeCacheSection(p *parser.Parser, data *models.Cache) error {
+	for attribute, field := range cacheNumbers(data) {
+		if *field == nil {
+			if err := p.Set(parser.Cache, data.Name, attribute, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.Set(parser.Cache, data.Name, attribute, types.Int64C{Value: **field}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cacheNumbers maps the cache section keywords to the model fields
+func cacheNumbers(cache *models.Cache) map[string]**int64 {
+	return map[string]**int64{
+		"total-max-size":  &cache.TotalMaxSize,
+		"max-object-size": &cache.MaxObjectSize,
+		"max-age":         &cache.MaxAge,
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestGetCaches(t *testing.T) {
+	v, caches, err := client.GetCaches("")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if len(caches) != 1 {
+		t.Errorf("%v caches returned, expected 1", len(caches))
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	expected := &models.Cache{
+		Name:          "mycache",
+		TotalMaxSize:  misc.Int64P(4),
+		MaxObjectSize: misc.Int64P(524288),
+		MaxAge:        misc.Int64P(240),
+	}
+	if !reflect.DeepEqual(caches[0], expected) {
+		t.Errorf("Cache %v returned, expected %v", caches[0], expected)
+	}
+}
+
+func TestCreateEditDeleteCache(t *testing.T) {
+	c := &models.Cache{
+		Name:         "static",
+		TotalMaxSize: misc.Int64P(64),
+		MaxAge:       misc.Int64P(3600),
+	}
+	err := client.CreateCache(c, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	v, cache, err := client.GetCache("static", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(c, cache) {
+		t.Errorf("Created cache %v not equal to given cache %v", cache, c)
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	err = client.CreateCache(c, "", version)
+	if err == nil {
+		t.Error("Should throw error cache already exists")
+		version++
+	}
+
+	err = client.CreateCache(&models.Cache{Name: "toobig", TotalMaxSize: misc.Int64P(4096)}, "", version)
+	if err == nil {
+		t.Error("Should throw error, total-max-size over 4095")
+		version++
+	}
+
+	// a cache filter can only reference an existing cache
+	err = client.CreateFilter("frontend", "test", &models.Filter{Type: "cache", CacheName: "doesnotexist"}, "", version)
+	if err == nil {
+		t.Error("Should throw error, cache section does not exist")
+		version++
+	}
+
+	c = &models.Cache{
+		Name:          "static",
+		TotalMaxSize:  misc.Int64P(128),
+		MaxObjectSize: misc.Int64P(1048576),
+	}
+	err = client.EditCache("static", c, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, cache, err = client.GetCache("static", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(c, cache) {
+		t.Errorf("Edited cache %v not equal to given cache %v", cache, c)
+	}
+
+	err = client.DeleteCache("static", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetCache("static", "")
+	if err == nil {
+		t.Error("DeleteCache failed, cache static still exists")
+	}
+
+	err = client.DeleteCache("static", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existant cache")
+		version++
+	}
+}
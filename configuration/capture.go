@@ -0,0 +1,341 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	parser_errors "github.com/haproxytech/config-parser/v3/errors"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetCaptures returns configuration version and an array of configured
+// captures in the specified frontend, in the order they are declared. Request
+// and response captures are numbered separately. Returns error on fail.
+func (c *Client) GetCaptures(frontend string, transactionID string) (int64, models.Captures, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+	}
+
+	captures, err := ParseCaptures(frontend, p)
+	if err != nil {
+		return v, nil, c.HandleError("", "frontend", frontend, "", false, err)
+	}
+
+	return v, captures, nil
+}
+
+// GetCapture returns configuration version and the capture in slot id of the
+// captures of captureType in the specified frontend. Returns error on fail or if
+// capture does not exist.
+func (c *Client) GetCapture(id int64, captureType string, frontend string, transactionID string) (int64, *models.Capture, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+	}
+
+	lines, slots, err := getCaptureLines(frontend, captureType, p)
+	if err != nil {
+		return v, nil, c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, "", false, err)
+	}
+	if id < 0 || id >= int64(len(slots)) {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Capture %s %d does not exist in frontend %s", captureType, id, frontend))
+	}
+
+	capture := ParseCapture(lines[slots[id]].Value)
+	capture.Index = &id
+	return v, capture, nil
+}
+
+// DeleteCapture deletes the capture in slot id of the captures of captureType,
+// captures of that type after it move up one slot. One of version or
+// transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteCapture(id int64, captureType string, frontend string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", e)
+	}
+
+	lines, slots, err := getCaptureLines(frontend, captureType, p)
+	if err != nil {
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", err)
+	}
+	if id < 0 || id >= int64(len(slots)) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Capture %s %d does not exist in frontend %s", captureType, id, frontend))
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", e)
+	}
+
+	i := slots[id]
+	lines = append(lines[:i], lines[i+1:]...)
+	if err := p.Set(parser.Frontends, frontend, "", lines); err != nil {
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateCapture creates a capture in configuration at data.Index among the
+// captures of data.Type, captures of that type at that position and after it are
+// moved down one slot, a nil index appends the capture. One of version or
+// transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateCapture(frontend string, data *models.Capture, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError(strconv.FormatInt(index, 10), "frontend", frontend, t, transactionID == "", e)
+	}
+
+	lines, all, err := getCaptureLines(frontend, "", p)
+	if err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), "frontend", frontend, t, transactionID == "", err)
+	}
+	_, slots, err := getCaptureLines(frontend, data.Type, p)
+	if err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), "frontend", frontend, t, transactionID == "", err)
+	}
+
+	// captures are appended after the last one of their type, or the last
+	// capture if there is none yet, so they stay grouped
+	i := len(lines)
+	switch {
+	case index >= 0 && index < int64(len(slots)):
+		i = slots[index]
+	case len(slots) > 0:
+		i = slots[len(slots)-1] + 1
+	case len(all) > 0:
+		i = all[len(all)-1] + 1
+	}
+	line := types.UnProcessed{Value: SerializeCapture(*data)}
+	lines = append(lines[:i], append([]types.UnProcessed{line}, lines[i:]...)...)
+
+	if err := p.Set(parser.Frontends, frontend, "", lines); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditCapture edits the capture in slot id of the captures of data.Type, its
+// slot is kept. One of version or transactionID is mandatory. Returns error on
+// fail, nil on success.
+func (c *Client) EditCapture(id int64, frontend string, data *models.Capture, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Frontends, frontend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", e)
+	}
+
+	lines, slots, err := getCaptureLines(frontend, data.Type, p)
+	if err != nil {
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", err)
+	}
+	if id < 0 || id >= int64(len(slots)) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Capture %s %d does not exist in frontend %s", data.Type, id, frontend))
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", e)
+	}
+
+	lines[slots[id]] = types.UnProcessed{Value: SerializeCapture(*data)}
+	if err := p.Set(parser.Frontends, frontend, "", lines); err != nil {
+		return c.HandleError(strconv.FormatInt(id, 10), "frontend", frontend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getCaptureLines returns the unprocessed lines of a frontend and the positions
+// of the capture lines of captureType among them, of all captures if captureType
+// is empty. config-parser has no parsers for captures, so they are kept among the
+// unprocessed lines in the order they are declared.
+func getCaptureLines(frontend string, captureType string, p *parser.Parser) ([]types.UnProcessed, []int, error) {
+	lines := []types.UnProcessed{}
+	slots := []int{}
+
+	data, err := p.Get(parser.Frontends, frontend, "", false)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrFetch) {
+			return lines, slots, nil
+		}
+		return nil, nil, err
+	}
+
+	lines = data.([]types.UnProcessed)
+	for i, line := range lines {
+		capture := ParseCapture(line.Value)
+		if capture != nil && (captureType == "" || capture.Type == captureType) {
+			slots = append(slots, i)
+		}
+	}
+	return lines, slots, nil
+}
+
+func ParseCaptures(frontend string, p *parser.Parser) (models.Captures, error) {
+	captures := models.Captures{}
+
+	lines, slots, err := getCaptureLines(frontend, "", p)
+	if err != nil {
+		return nil, err
+	}
+
+	// HAProxy numbers the request and the response capture slots separately
+	next := map[string]int64{}
+	for _, slot := range slots {
+		capture := ParseCapture(lines[slot].Value)
+		id := next[capture.Type]
+		next[capture.Type]++
+		capture.Index = &id
+		captures = append(captures, capture)
+	}
+	return captures, nil
+}
+
+// SerializeCaptures replaces the captures of a frontend with the given ones, in
+// their order in the list. The new capture lines take the place of the first old
+// one, the other unprocessed lines of the frontend are kept as they are.
+func SerializeCaptures(frontend string, captures models.Captures, p *parser.Parser) error {
+	lines, slots, err := getCaptureLines(frontend, "", p)
+	if err != nil {
+		return err
+	}
+
+	at := len(lines)
+	if len(slots) > 0 {
+		at = slots[0]
+	}
+	kept := []types.UnProcessed{}
+	for i, line := range lines {
+		if i == at {
+			for _, c := range captures {
+				kept = append(kept, types.UnProcessed{Value: SerializeCapture(*c)})
+			}
+		}
+		if ParseCapture(line.Value) == nil {
+			kept = append(kept, line)
+		}
+	}
+	if at == len(lines) {
+		for _, c := range captures {
+			kept = append(kept, types.UnProcessed{Value: SerializeCapture(*c)})
+		}
+	}
+	return p.Set(parser.Frontends, frontend, "", kept)
+}
+
+// ParseCapture parses a "declare capture <type> len <length>" or a
+// "capture <type> header <name> len <length>" line, it returns nil for other lines.
+func ParseCapture(line string) *models.Capture {
+	parts := strings.Fields(line)
+	var capture *models.Capture
+	switch {
+	case len(parts) == 5 && parts[0] == "declare" && parts[1] == "capture" && parts[3] == "len":
+		capture = &models.Capture{Type: parts[2]}
+		parts = parts[4:]
+	case len(parts) == 6 && parts[0] == "capture" && parts[2] == "header" && parts[4] == "len":
+		capture = &models.Capture{Type: parts[1], Header: parts[3]}
+		parts = parts[5:]
+	default:
+		return nil
+	}
+	if capture.Type != "request" && capture.Type != "response" {
+		return nil
+	}
+	length, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	capture.Length = length
+	return capture
+}
+
+func SerializeCapture(capture models.Capture) string {
+	if capture.Header == "" {
+		return fmt.Sprintf("declare capture %s len %d", capture.Type, capture.Length)
+	}
+	return fmt.Sprintf("capture %s header %s len %d", capture.Type, capture.Header, capture.Length)
+}
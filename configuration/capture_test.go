@@ -0,0 +1,163 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestCaptureOrder(t *testing.T) {
+	captures := []*models.Capture{
+		{Type: "request", Header: "Host", Length: 64, Index: misc.Int64P(0)},
+		{Type: "response", Header: "Content-Type", Length: 32, Index: misc.Int64P(0)},
+		{Type: "request", Length: 16, Index: misc.Int64P(0)},
+		{Type: "response", Header: "Server", Length: 32, Index: misc.Int64P(0)},
+	}
+	for _, c := range captures {
+		if err := client.CreateCapture("test", c, "", version); err != nil {
+			t.Fatal(err.Error())
+		}
+		version++
+	}
+
+	v, got, err := client.GetCaptures("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+	// request and response captures have their own slots
+	expected := models.Captures{
+		{Index: misc.Int64P(0), Type: "request", Length: 16},
+		{Index: misc.Int64P(1), Type: "request", Header: "Host", Length: 64},
+		{Index: misc.Int64P(0), Type: "response", Header: "Server", Length: 32},
+		{Index: misc.Int64P(1), Type: "response", Header: "Content-Type", Length: 32},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Captures %v returned, expected %v", got, expected)
+	}
+
+	edited := &models.Capture{Index: misc.Int64P(1), Type: "request", Header: "User-Agent", Length: 128}
+	if err := client.EditCapture(1, "test", edited, "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, capture, err := client.GetCapture(1, "request", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(capture, edited) {
+		t.Errorf("Capture %v returned, expected %v", capture, edited)
+	}
+
+	if err := client.DeleteCapture(0, "request", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	// the following request captures move up one slot, response ones are kept
+	_, capture, err = client.GetCapture(0, "request", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if capture.Header != "User-Agent" {
+		t.Errorf("Capture of %v in request slot 0, expected User-Agent", capture.Header)
+	}
+	_, capture, err = client.GetCapture(1, "response", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if capture.Header != "Content-Type" {
+		t.Errorf("Capture of %v in response slot 1, expected Content-Type", capture.Header)
+	}
+
+	if _, _, err := client.GetCapture(1, "request", "test", ""); err == nil {
+		t.Error("Should throw error, request capture 1 does not exist")
+	}
+	if err := client.CreateCapture("doesnotexist", captures[0], "", version); err == nil {
+		t.Error("Should throw error, frontend does not exist")
+		version++
+	}
+
+	for _, captureType := range []string{"request", "response", "response"} {
+		if err := client.DeleteCapture(0, captureType, "test", "", version); err != nil {
+			t.Error(err.Error())
+		} else {
+			version++
+		}
+	}
+
+	if err := client.DeleteCapture(0, "request", "test", "", version); err == nil {
+		t.Error("Should throw error, non existant capture")
+		version++
+	}
+}
+
+func TestStructuredCaptures(t *testing.T) {
+	path := "/tmp/haproxy-structured-captures.cfg"
+	if err := prepareTestFile(unmodeledConf, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	v, conf, err := c.GetStructuredConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	old, err := ParseStructuredConfiguration(c.Parser)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := models.Captures{
+		{Index: misc.Int64P(0), Type: "request", Header: "Host", Length: 64},
+		{Index: misc.Int64P(0), Type: "response", Length: 32},
+	}
+	if !reflect.DeepEqual(conf.Frontends[0].Captures, expected) {
+		t.Fatalf("Structured captures %v, expected %v", conf.Frontends[0].Captures, expected)
+	}
+
+	ua := &models.Capture{Index: misc.Int64P(1), Type: "request", Header: "User-Agent", Length: 128}
+	conf.Frontends[0].Captures = append(conf.Frontends[0].Captures, ua)
+	if err := c.PushStructuredConfiguration(conf, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, capture, err := c.GetCapture(1, "request", "public", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(capture, ua) {
+		t.Errorf("Capture %v in request slot 1, expected %v", capture, ua)
+	}
+
+	diff := DiffStructuredConfigurations(old, conf)
+	if len(diff) != 1 || diff[0].Action != "added" || diff[0].ObjectType != "captures" || diff[0].ObjectID != "2" {
+		b, _ := json.Marshal(diff)
+		t.Errorf("Diff %s, expected the capture added", b)
+	}
+}
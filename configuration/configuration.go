@@ -16,12 +16,19 @@
 package configuration
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	parser "github.com/haproxytech/config-parser/v3"
 	"github.com/haproxytech/config-parser/v3/common"
 	parser_errors "github.com/haproxytech/config-parser/v3/errors"
@@ -49,6 +56,8 @@ const (
 	DefaultTransactionDir string = "/etc/haproxy/transactions"
 	// DefaultValidateConfigurationFile is used to validate HAProxy configuration file
 	DefaultValidateConfigurationFile bool = true
+	// DefaultCheckTimeout sane default for the run time of the HAProxy configuration check
+	DefaultCheckTimeout time.Duration = 30 * time.Second
 )
 
 // ClientParams is just a placeholder for all client options
@@ -64,9 +73,21 @@ type ClientParams struct {
 	SkipFailedTransactions    bool
 	UseMd5Hash                bool
 
+	// SkipSemanticValidation turns off the checks of option combinations that run
+	// after the schema validation when UseValidation is set.
+	SkipSemanticValidation bool
+
 	// ValidateCmd allows specifying a custom script to validate the transaction file.
 	// The injected environment variable DATAPLANEAPI_TRANSACTION_FILE must be used to get the location of the file.
 	ValidateCmd string
+
+	// TransactionTTL is the age of a transaction file after which CleanupTransactions
+	// deletes it, zero turns the cleanup off.
+	TransactionTTL time.Duration
+
+	// CheckTimeout limits the run time of the HAProxy check or ValidateCmd, the
+	// check fails when it is exceeded. DefaultCheckTimeout is used when zero.
+	CheckTimeout time.Duration
 }
 
 // Client configuration client
@@ -75,11 +96,26 @@ type ClientParams struct {
 // parsers map contains a config parser for each transaction, which loads data from
 // transaction files on StartTransaction, and deletes on CommitTransaction. We save
 // data to file on every change for persistence.
+//
+// A Client can be used from multiple goroutines. Access to the parsers map and to the
+// master Parser is guarded, and every transaction has its own parser, so reads of
+// different transactions never see each other's data. Changes to the same transaction
+// from multiple goroutines are not ordered, callers have to serialize them. Parser
+// should not be accessed directly once the Client is shared, use GetParser("").
 type Client struct {
 	Transaction
-	parsers  map[string]*parser.Parser
-	services map[string]*Service
-	Parser   *parser.Parser
+	parsersMu sync.RWMutex
+	parsers   map[string]*parser.Parser
+	services  map[string]*Service
+	Parser    *parser.Parser
+	// snapshot of Parser, dropped when Parser changes
+	snapshot *Snapshot
+	// generation is incremented on every change of Parser, including reloads
+	// into the same parser, a snapshot is kept only if it did not change
+	generation uint64
+
+	validatorsMu sync.RWMutex
+	validators   []func(interface{}) error
 }
 
 // DefaultClient returns Client with sane defaults
@@ -154,6 +190,8 @@ func (c *Client) Init(options ClientParams) error {
 
 // HasParser checks whether transaction exists in parser
 func (c *Client) HasParser(transactionID string) bool {
+	c.parsersMu.RLock()
+	defer c.parsersMu.RUnlock()
 	_, ok := c.parsers[transactionID]
 	return ok
 }
@@ -161,22 +199,23 @@ func (c *Client) HasParser(transactionID string) bool {
 // GetParserTransactions returns parser transactions
 func (c *Client) GetParserTransactions() models.Transactions {
 	transactions := models.Transactions{}
-	for tID := range c.parsers {
-		v, err := c.GetVersion(tID)
-		if err == nil {
-			t := &models.Transaction{
-				ID:      tID,
-				Status:  models.TransactionStatusInProgress,
-				Version: v,
-			}
-			transactions = append(transactions, t)
+	c.parsersMu.RLock()
+	defer c.parsersMu.RUnlock()
+	for tID, p := range c.parsers {
+		t := &models.Transaction{
+			ID:      tID,
+			Status:  models.TransactionStatusInProgress,
+			Version: parserVersion(p),
 		}
+		transactions = append(transactions, t)
 	}
 	return transactions
 }
 
 // GetParser returns a parser for given transactionID, if transactionID is "", it returns "master" parser
 func (c *Client) GetParser(transactionID string) (*parser.Parser, error) {
+	c.parsersMu.RLock()
+	defer c.parsersMu.RUnlock()
 	if transactionID == "" {
 		return c.Parser, nil
 	}
@@ -192,6 +231,8 @@ func (c *Client) AddParser(transactionID string) error {
 	if transactionID == "" {
 		return NewConfError(ErrValidationError, "Not a valid transaction")
 	}
+	c.parsersMu.Lock()
+	defer c.parsersMu.Unlock()
 	_, ok := c.parsers[transactionID]
 	if ok {
 		return NewConfError(ErrTransactionAlreadyExists, fmt.Sprintf("Transaction %s already exists", transactionID))
@@ -225,6 +266,8 @@ func (c *Client) DeleteParser(transactionID string) error {
 	if transactionID == "" {
 		return NewConfError(ErrValidationError, "Not a valid transaction")
 	}
+	c.parsersMu.Lock()
+	defer c.parsersMu.Unlock()
 	_, ok := c.parsers[transactionID]
 	if !ok {
 		return NewConfError(ErrTransactionDoesNotExist, fmt.Sprintf("Transaction %s does not exist", transactionID))
@@ -238,11 +281,14 @@ func (c *Client) CommitParser(transactionID string) error {
 	if transactionID == "" {
 		return NewConfError(ErrValidationError, "Not a valid transaction")
 	}
+	c.parsersMu.Lock()
+	defer c.parsersMu.Unlock()
 	p, ok := c.parsers[transactionID]
 	if !ok {
 		return NewConfError(ErrTransactionDoesNotExist, fmt.Sprintf("Transaction %s does not exist", transactionID))
 	}
 	c.Parser = p
+	c.parserChanged()
 	delete(c.parsers, transactionID)
 	return nil
 }
@@ -273,6 +319,98 @@ func (c *Client) InitTransactionParsers() error {
 	return nil
 }
 
+// ValidateTransaction checks a transaction without committing it. The transaction
+// file is parsed again, validated against the schema when UseValidation is set and
+// checked with HAProxy when ValidateConfigurationFile is set. Returns nil when
+// the transaction would pass these checks on commit.
+func (c *Client) ValidateTransaction(transactionID string) error {
+	if transactionID == "" {
+		return NewConfError(ErrValidationError, "Not a valid transaction")
+	}
+	tp, err := c.GetParser(transactionID)
+	if err != nil {
+		return err
+	}
+
+	data := tp.String()
+	tFile := ""
+	if c.PersistentTransactions {
+		if tFile, err = c.GetTransactionFile(transactionID); err != nil {
+			return err
+		}
+		b, err := ioutil.ReadFile(tFile)
+		if err != nil {
+			return NewConfError(ErrCannotReadConfFile, fmt.Sprintf("Cannot read %s", tFile))
+		}
+		data = string(b)
+	}
+
+	p := &parser.Parser{
+		Options: parser.Options{
+			UseV2HTTPCheck: true,
+			UseMd5Hash:     c.ClientParams.UseMd5Hash,
+		},
+	}
+	if err := p.ParseData(data); err != nil {
+		return NewConfError(ErrCannotParseTransaction, fmt.Sprintf("Cannot parse transaction %s: %s", transactionID, err.Error()))
+	}
+	conf, err := ParseStructuredConfiguration(p)
+	if err != nil {
+		return NewConfError(ErrCannotParseTransaction, fmt.Sprintf("Cannot parse transaction %s: %s", transactionID, err.Error()))
+	}
+
+	if c.UseValidation {
+		if err := conf.Validate(strfmt.Default); err != nil {
+			return NewValidationError(err)
+		}
+		if !c.SkipSemanticValidation {
+			if err := validateStructuredConfiguration(conf); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !c.ValidateConfigurationFile {
+		return nil
+	}
+	return c.CheckTransaction(transactionID)
+}
+
+// CheckTransaction runs the HAProxy configuration check, or ValidateCmd if set, on
+// the transaction without committing it, whatever ValidateConfigurationFile is set to.
+// The check is stopped after CheckTimeout. Returns a validation error with one sub
+// error per problem reported by HAProxy on fail, nil on success.
+func (c *Client) CheckTransaction(transactionID string) error {
+	if transactionID == "" {
+		return NewConfError(ErrValidationError, "Not a valid transaction")
+	}
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return err
+	}
+
+	if c.PersistentTransactions {
+		tFile, err := c.GetTransactionFile(transactionID)
+		if err != nil {
+			return err
+		}
+		return c.checkConfigurationFile(tFile, transactionID)
+	}
+
+	// transaction is only kept in memory, check a temporary copy
+	f, err := ioutil.TempFile("", filepath.Base(c.ConfigurationFile))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(p.String())
+	f.Close()
+	if err != nil {
+		return err
+	}
+	return c.checkConfigurationFile(f.Name(), transactionID)
+}
+
 // GetVersion returns configuration file version
 func (c *Client) GetVersion(transactionID string) (int64, error) {
 	return c.getVersion(transactionID)
@@ -283,27 +421,32 @@ func (c *Client) getVersion(transactionID string) (int64, error) {
 	if err != nil {
 		return 0, NewConfError(ErrCannotReadVersion, fmt.Sprintf("Cannot read version: %s", err.Error()))
 	}
+	return parserVersion(p), nil
+}
 
+func parserVersion(p *parser.Parser) int64 {
 	data, _ := p.Get(parser.Comments, parser.CommentsSectionName, "# _version", true)
 	ver, _ := data.(*types.ConfigVersion)
-	return ver.Value, nil
+	return ver.Value
 }
 
 func (c *Client) IncrementVersion() error {
-	data, _ := c.Parser.Get(parser.Comments, parser.CommentsSectionName, "# _version", true)
+	p, _ := c.GetParser("")
+	data, _ := p.Get(parser.Comments, parser.CommentsSectionName, "# _version", true)
 	ver, _ := data.(*types.ConfigVersion)
 	ver.Value++
 
-	if err := c.Parser.Save(c.ConfigurationFile); err != nil {
+	c.parsersMu.Lock()
+	c.parserChanged()
+	c.parsersMu.Unlock()
+
+	if err := p.Save(c.ConfigurationFile); err != nil {
 		return NewConfError(ErrCannotSetVersion, fmt.Sprintf("Cannot set version: %s", err.Error()))
 	}
 	return nil
 }
 
 func (c *Client) IncrementTransactionVersion(transactionID string) error {
-	if transactionID == "" {
-		return c.incrementTransactionVersion(c.Parser)
-	}
 	p, err := c.GetParser(transactionID)
 	if err != nil {
 		return err
@@ -322,17 +465,24 @@ func (c *Client) incrementTransactionVersion(p *parser.Parser) error {
 }
 
 func (c *Client) LoadData(filename string) error {
-	err := c.Parser.LoadData(filename)
+	p, _ := c.GetParser("")
+	err := p.LoadData(filename)
+	c.parsersMu.Lock()
+	c.parserChanged()
+	c.parsersMu.Unlock()
 	if err != nil {
 		return NewConfError(ErrCannotReadConfFile, fmt.Sprintf("cannot read %s", filename))
 	}
 	return nil
 }
 
+// parserChanged drops the snapshot of Parser, parsersMu has to be held
+func (c *Client) parserChanged() {
+	c.generation++
+	c.snapshot = nil
+}
+
 func (c *Client) Save(transactionFile, transactionID string) error {
-	if transactionID == "" {
-		return c.Parser.Save(transactionFile)
-	}
 	p, err := c.GetParser(transactionID)
 	if err != nil {
 		return err
@@ -445,6 +595,8 @@ func (s *SectionParser) checkSpecialFields(fieldName string) (match bool, data i
 		return true, s.hashType()
 	case "ErrorFiles":
 		return true, s.errorFiles()
+	case "ErrorFilesFromHTTPErrors":
+		return true, s.errorFilesFromHTTPErrors()
 	case "DefaultServer":
 		return true, s.defaultServer()
 	case "StickTable":
@@ -981,21 +1133,47 @@ func (s *SectionParser) errorFiles() interface{} {
 		return nil
 	}
 	d := data.([]types.ErrorFile)
-	if s.Section == parser.Defaults {
-		dEFiles := []*models.Errorfile{}
-		for _, ef := range d {
-			dEFile := &models.Errorfile{}
-			code, err := strconv.ParseInt(ef.Code, 10, 64)
+	dEFiles := []*models.Errorfile{}
+	for _, ef := range d {
+		dEFile := &models.Errorfile{}
+		code, err := strconv.ParseInt(ef.Code, 10, 64)
+		if err != nil {
+			continue
+		}
+		dEFile.Code = code
+		dEFile.File = ef.File
+		dEFiles = append(dEFiles, dEFile)
+	}
+	return dEFiles
+}
+
+// errorFilesFromHTTPErrors reads the errorfiles lines, config-parser has no
+// parser for them so they are kept among the unprocessed lines of the section
+func (s *SectionParser) errorFilesFromHTTPErrors() interface{} {
+	data, err := s.get("", false)
+	if err != nil {
+		return nil
+	}
+	efs := []*models.Errorfiles{}
+	for _, line := range data.([]types.UnProcessed) {
+		parts := strings.Fields(line.Value)
+		if len(parts) < 2 || parts[0] != "errorfiles" {
+			continue
+		}
+		ef := &models.Errorfiles{Name: parts[1]}
+		for _, c := range parts[2:] {
+			code, err := strconv.ParseInt(c, 10, 64)
 			if err != nil {
 				continue
 			}
-			dEFile.Code = code
-			dEFile.File = ef.File
-			dEFiles = append(dEFiles, dEFile)
+			ef.Codes = append(ef.Codes, code)
 		}
-		return dEFiles
+		efs = append(efs, ef)
 	}
-	return nil
+	if len(efs) == 0 {
+		return nil
+	}
+	return efs
 }
 
 func (s *SectionParser) hashType() interface{} {
@@ -1287,6 +1465,8 @@ func (s *SectionObject) checkSpecialFields(fieldName string, field reflect.Value
 		return true, s.hashType(field)
 	case "ErrorFiles":
 		return true, s.errorFiles(field)
+	case "ErrorFilesFromHTTPErrors":
+		return true, s.errorFilesFromHTTPErrors(field)
 	case "DefaultServer":
 		return true, s.defaultServer(field)
 	case "StickTable":
@@ -2061,26 +2241,61 @@ func (s *SectionObject) defaultServer(field reflect.Value) error { //nolint:goco
 }
 
 func (s *SectionObject) errorFiles(field reflect.Value) error {
-	if s.Section == parser.Defaults {
-		if valueIsNil(field) {
-			if err := s.set("errorfile", nil); err != nil {
-				return err
+	if valueIsNil(field) {
+		if err := s.set("errorfile", nil); err != nil {
+			return err
+		}
+		return nil
+	}
+	efs, ok := field.Interface().([]*models.Errorfile)
+	if !ok {
+		return nil
+	}
+	errorFiles := []types.ErrorFile{}
+	for _, ef := range efs {
+		errorFiles = append(errorFiles, types.ErrorFile{Code: strconv.FormatInt(ef.Code, 10), File: ef.File})
+	}
+	if err := s.set("errorfile", errorFiles); err != nil {
+		return err
+	}
+	return nil
+}
+
+// errorFilesFromHTTPErrors replaces the errorfiles lines among the unprocessed
+// lines of the section, the other unprocessed lines are kept as they are. The new
+// lines take the place of the first old one, so an unchanged section stays as it is.
+func (s *SectionObject) errorFilesFromHTTPErrors(field reflect.Value) error {
+	lines := []types.UnProcessed{}
+	at := -1
+	data, err := s.Parser.Get(s.Section, s.Name, "", false)
+	if err == nil {
+		for _, line := range data.([]types.UnProcessed) {
+			if !strings.HasPrefix(line.Value, "errorfiles ") {
+				lines = append(lines, line)
+			} else if at < 0 {
+				at = len(lines)
 			}
-			return nil
 		}
-		efs, ok := field.Interface().([]*models.Errorfile)
+	}
+	if at < 0 {
+		at = len(lines)
+	}
+	efLines := []types.UnProcessed{}
+	if !valueIsNil(field) {
+		efs, ok := field.Interface().([]*models.Errorfiles)
 		if !ok {
 			return nil
 		}
-		errorFiles := []types.ErrorFile{}
 		for _, ef := range efs {
-			errorFiles = append(errorFiles, types.ErrorFile{Code: strconv.FormatInt(ef.Code, 10), File: ef.File})
-		}
-		if err := s.set("errorfile", errorFiles); err != nil {
-			return err
+			line := []string{"errorfiles", ef.Name}
+			for _, code := range ef.Codes {
+				line = append(line, strconv.FormatInt(code, 10))
+			}
+			efLines = append(efLines, types.UnProcessed{Value: strings.Join(line, " ")})
 		}
 	}
-	return nil
+	lines = append(lines[:at], append(efLines, lines[at:]...)...)
+	return s.set("", lines)
 }
 
 func (s *SectionObject) hashType(field reflect.Value) error {
@@ -2262,7 +2477,13 @@ func (s *SectionObject) httpCheck(field reflect.Value) error {
 			Type:            *hc.Type,
 		}
 
-		if err := s.set("http-check", d); err != nil {
+		// only the last http-check line is read into the model, so it is the one
+		// replaced, appending would add a line on every edit
+		index := -1
+		if data, err := s.Parser.Get(s.Section, s.Name, "http-check", false); err == nil {
+			index = len(data.([]types.HTTPCheckV2)) - 1
+		}
+		if err := s.Parser.Set(s.Section, s.Name, "http-check", d, index); err != nil {
 			return err
 		}
 	}
@@ -2427,6 +2648,33 @@ func (c *Client) createSection(section parser.Section, name string, data interfa
 	return nil
 }
 
+// RegisterValidator adds a validator for objects given to the Create and Edit
+// methods, used to enforce policies beyond the model schema, e.g. that every
+// bind uses ssl. The validator receives the model pointer, such as *models.Bind.
+//
+// Validators run only when UseValidation is set: after the model schema
+// validation passed and before the semantic checks of the client, in the order
+// they were registered. The first error stops the change and is returned as a
+// ConfError with the ErrValidationError code. Batch methods run them on every
+// object of the batch.
+func (c *Client) RegisterValidator(validator func(interface{}) error) {
+	c.validatorsMu.Lock()
+	defer c.validatorsMu.Unlock()
+	c.validators = append(c.validators, validator)
+}
+
+// runValidators runs the registered validators on data
+func (c *Client) runValidators(data interface{}) error {
+	c.validatorsMu.RLock()
+	defer c.validatorsMu.RUnlock()
+	for _, validator := range c.validators {
+		if err := validator(data); err != nil {
+			return NewValidationError(err)
+		}
+	}
+	return nil
+}
+
 func (c *Client) checkSectionExists(section parser.Section, sectionName string, p *parser.Parser) bool {
 	sections, err := p.SectionsGet(section)
 	if err != nil {
@@ -2440,6 +2688,14 @@ func (c *Client) checkSectionExists(section parser.Section, sectionName string,
 }
 
 func (c *Client) loadDataForChange(transactionID string, version int64) (*parser.Parser, string, error) {
+	return c.loadDataForChangeCtx(context.Background(), transactionID, version)
+}
+
+func (c *Client) loadDataForChangeCtx(ctx context.Context, transactionID string, version int64) (*parser.Parser, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
 	t, err := c.TransactionClient.CheckTransactionOrVersion(transactionID, version)
 	if err != nil {
 		// if transactionID is implicit, return err and delete transaction
@@ -2459,6 +2715,16 @@ func (c *Client) loadDataForChange(transactionID string, version int64) (*parser
 	return p, t, nil
 }
 
+// splitHostPort splits an address at its last colon, so IPv6 addresses such as
+// 2001:db8::1:53 keep their colons in host. ok is false if there is no colon.
+func splitHostPort(address string) (host, port string, ok bool) {
+	i := strings.LastIndex(address, ":")
+	if i < 0 {
+		return address, "", false
+	}
+	return address[:i], address[i+1:], true
+}
+
 func valueIsNil(v reflect.Value) bool {
 	switch v.Kind() { //nolint:exhaustive
 	case reflect.Int64:
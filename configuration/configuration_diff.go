@@ -0,0 +1,216 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// DiffTransactions returns the changes in the configuration of toTransactionID compared to
+// the configuration of fromTransactionID, an empty transaction ID stands for the committed
+// configuration. Returns error on fail.
+func (c *Client) DiffTransactions(fromTransactionID, toTransactionID string) (models.ConfigurationDiff, error) {
+	_, from, err := c.GetStructuredConfiguration(fromTransactionID)
+	if err != nil {
+		return nil, err
+	}
+	_, to, err := c.GetStructuredConfiguration(toTransactionID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffStructuredConfigurations(from, to), nil
+}
+
+// DiffStructuredConfigurations returns the sections and objects added, removed or modified
+// in to compared to from. Binds, servers, nameservers and peer entries are matched by name,
+// rules and other objects without a name by their position.
+func DiffStructuredConfigurations(from, to *models.Configuration) models.ConfigurationDiff {
+	diff := models.ConfigurationDiff{}
+	if !reflect.DeepEqual(from.Global, to.Global) {
+		diff = append(diff, &models.ConfigurationChange{
+			Action:      models.ConfigurationChangeActionModified,
+			SectionType: models.ConfigurationChangeSectionTypeGlobal,
+			Old:         from.Global,
+			New:         to.Global,
+		})
+	}
+	if !reflect.DeepEqual(from.Defaults, to.Defaults) {
+		diff = append(diff, &models.ConfigurationChange{
+			Action:      models.ConfigurationChangeActionModified,
+			SectionType: models.ConfigurationChangeSectionTypeDefaults,
+			Old:         from.Defaults,
+			New:         to.Defaults,
+		})
+	}
+	diff = append(diff, diffSections(models.ConfigurationChangeSectionTypeFrontend, from.Frontends, to.Frontends)...)
+	diff = append(diff, diffSections(models.ConfigurationChangeSectionTypeBackend, from.Backends, to.Backends)...)
+	diff = append(diff, diffSections(models.ConfigurationChangeSectionTypeResolvers, from.Resolvers, to.Resolvers)...)
+	diff = append(diff, diffSections(models.ConfigurationChangeSectionTypePeers, from.PeerSections, to.PeerSections)...)
+	return diff
+}
+
+// diffSections compares two slices of structured sections of one type,
+// for example []*models.ConfigurationFrontend
+func diffSections(sectionType string, from, to interface{}) models.ConfigurationDiff {
+	fromSections := structuredSectionsByName(from)
+	toSections := structuredSectionsByName(to)
+
+	names := []string{}
+	for name := range fromSections {
+		names = append(names, name)
+	}
+	for name := range toSections {
+		if _, ok := fromSections[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diff := models.ConfigurationDiff{}
+	for _, name := range names {
+		f, inFrom := fromSections[name]
+		t, inTo := toSections[name]
+		switch {
+		case !inTo:
+			diff = append(diff, &models.ConfigurationChange{
+				Action:      models.ConfigurationChangeActionRemoved,
+				SectionType: sectionType,
+				SectionName: name,
+				Old:         f.Addr().Interface(),
+			})
+		case !inFrom:
+			diff = append(diff, &models.ConfigurationChange{
+				Action:      models.ConfigurationChangeActionAdded,
+				SectionType: sectionType,
+				SectionName: name,
+				New:         t.Addr().Interface(),
+			})
+		default:
+			diff = append(diff, diffSection(sectionType, name, f, t)...)
+		}
+	}
+	return diff
+}
+
+// diffSection compares the section fields and the object lists of a structured section
+func diffSection(sectionType, name string, from, to reflect.Value) models.ConfigurationDiff {
+	diff := models.ConfigurationDiff{}
+	for i := 0; i < from.NumField(); i++ {
+		f := from.Field(i)
+		t := to.Field(i)
+		if f.Kind() == reflect.Ptr {
+			if !reflect.DeepEqual(f.Interface(), t.Interface()) {
+				diff = append(diff, &models.ConfigurationChange{
+					Action:      models.ConfigurationChangeActionModified,
+					SectionType: sectionType,
+					SectionName: name,
+					Old:         f.Interface(),
+					New:         t.Interface(),
+				})
+			}
+			continue
+		}
+		objectType := strings.Split(from.Type().Field(i).Tag.Get("json"), ",")[0]
+		for _, change := range diffObjects(f, t) {
+			change.SectionType = sectionType
+			change.SectionName = name
+			change.ObjectType = objectType
+			diff = append(diff, change)
+		}
+	}
+	return diff
+}
+
+// diffObjects compares two slices of objects of one type, named objects are
+// matched by their name, other objects by their position in the slice
+func diffObjects(from, to reflect.Value) models.ConfigurationDiff {
+	fromObjects, fromIDs := objectsByID(from)
+	toObjects, toIDs := objectsByID(to)
+
+	ids := fromIDs
+	for _, id := range toIDs {
+		if _, ok := fromObjects[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	diff := models.ConfigurationDiff{}
+	for _, id := range ids {
+		f, inFrom := fromObjects[id]
+		t, inTo := toObjects[id]
+		switch {
+		case !inTo:
+			diff = append(diff, &models.ConfigurationChange{Action: models.ConfigurationChangeActionRemoved, ObjectID: id, Old: f})
+		case !inFrom:
+			diff = append(diff, &models.ConfigurationChange{Action: models.ConfigurationChangeActionAdded, ObjectID: id, New: t})
+		case !reflect.DeepEqual(f, t):
+			diff = append(diff, &models.ConfigurationChange{Action: models.ConfigurationChangeActionModified, ObjectID: id, Old: f, New: t})
+		}
+	}
+	return diff
+}
+
+// namedObjects are the objects identified by their name. Other objects may have
+// a name field that does not identify them, like the backend of a use_backend rule.
+var namedObjects = map[reflect.Type]bool{
+	reflect.TypeOf(models.Bind{}):       true,
+	reflect.TypeOf(models.Server{}):     true,
+	reflect.TypeOf(models.Nameserver{}): true,
+	reflect.TypeOf(models.PeerEntry{}):  true,
+}
+
+func objectsByID(objects reflect.Value) (map[string]interface{}, []string) {
+	byID := map[string]interface{}{}
+	ids := []string{}
+	for i := 0; i < objects.Len(); i++ {
+		o := objects.Index(i)
+		if o.IsNil() {
+			continue
+		}
+		id := strconv.Itoa(i)
+		if namedObjects[o.Elem().Type()] {
+			id = o.Elem().FieldByName("Name").String()
+		}
+		byID[id] = o.Interface()
+		ids = append(ids, id)
+	}
+	return byID, ids
+}
+
+// structuredSectionsByName maps the structured sections in a slice to the name
+// of the section they hold
+func structuredSectionsByName(sections interface{}) map[string]reflect.Value {
+	byName := map[string]reflect.Value{}
+	s := reflect.ValueOf(sections)
+	for i := 0; i < s.Len(); i++ {
+		if s.Index(i).IsNil() {
+			continue
+		}
+		section := s.Index(i).Elem()
+		for j := 0; j < section.NumField(); j++ {
+			f := section.Field(j)
+			if f.Kind() == reflect.Ptr && !f.IsNil() {
+				byName[f.Elem().FieldByName("Name").String()] = section
+			}
+		}
+	}
+	return byName
+}
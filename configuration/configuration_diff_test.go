@@ -0,0 +1,175 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestDiffTransactions(t *testing.T) {
+	tr, err := client.StartTransaction(version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() {
+		if err := client.DeleteTransaction(tr.ID); err != nil {
+			t.Error(err.Error())
+		}
+	}()
+
+	bind := &models.Bind{Name: "diff_bind", Address: "127.0.0.1", Port: misc.Int64P(8443)}
+	if err := client.CreateBind("test", bind, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := client.DeleteServer("webserv2", "test", tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	diff, err := client.DiffTransactions("", tr.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(diff) != 2 {
+		b, _ := json.Marshal(diff)
+		t.Fatalf("%v changes returned, expected 2: %s", len(diff), b)
+	}
+
+	added := diff[0]
+	if added.Action != models.ConfigurationChangeActionAdded || added.SectionType != "frontend" || added.SectionName != "test" ||
+		added.ObjectType != "binds" || added.ObjectID != "diff_bind" || added.Old != nil {
+		t.Errorf("Unexpected change %v, expected bind diff_bind added to frontend test", *added)
+	}
+	if b, ok := added.New.(*models.Bind); !ok || *b.Port != 8443 {
+		t.Errorf("Added bind is %v", added.New)
+	}
+
+	removed := diff[1]
+	if removed.Action != models.ConfigurationChangeActionRemoved || removed.SectionType != "backend" || removed.SectionName != "test" ||
+		removed.ObjectType != "servers" || removed.ObjectID != "webserv2" || removed.New != nil {
+		t.Errorf("Unexpected change %v, expected server webserv2 removed from backend test", *removed)
+	}
+
+	// the reverse direction swaps added and removed
+	reverse, err := client.DiffTransactions(tr.ID, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(reverse) != 2 || reverse[0].Action != models.ConfigurationChangeActionRemoved || reverse[1].Action != models.ConfigurationChangeActionAdded {
+		t.Error("Reverse diff does not swap added and removed")
+	}
+
+	if _, err := client.DiffTransactions("", "missing_transaction"); err == nil {
+		t.Error("Should throw error, non existant transaction")
+	}
+}
+
+func TestDiffStructuredConfigurations(t *testing.T) {
+	from := &models.Configuration{
+		Backends: []*models.ConfigurationBackend{
+			{
+				Backend: &models.Backend{Name: "app", Mode: "http"},
+				HTTPRequestRules: models.HTTPRequestRules{
+					{Index: misc.Int64P(0), Type: "deny"},
+					{Index: misc.Int64P(1), Type: "allow"},
+				},
+			},
+			{Backend: &models.Backend{Name: "old"}},
+		},
+	}
+	to := &models.Configuration{
+		Global: &models.Global{Daemon: "enabled"},
+		Backends: []*models.ConfigurationBackend{
+			{
+				Backend: &models.Backend{Name: "app", Mode: "tcp"},
+				HTTPRequestRules: models.HTTPRequestRules{
+					{Index: misc.Int64P(0), Type: "deny"},
+					{Index: misc.Int64P(1), Type: "tarpit"},
+				},
+			},
+			{Backend: &models.Backend{Name: "new"}},
+		},
+	}
+
+	diff := DiffStructuredConfigurations(from, to)
+	expected := []struct {
+		action      string
+		sectionType string
+		sectionName string
+		objectType  string
+		objectID    string
+	}{
+		{"modified", "global", "", "", ""},
+		{"modified", "backend", "app", "", ""},
+		{"modified", "backend", "app", "http_request_rules", "1"},
+		{"added", "backend", "new", "", ""},
+		{"removed", "backend", "old", "", ""},
+	}
+	if len(diff) != len(expected) {
+		b, _ := json.Marshal(diff)
+		t.Fatalf("%v changes returned, expected %v: %s", len(diff), len(expected), b)
+	}
+	for i, e := range expected {
+		c := diff[i]
+		if c.Action != e.action || c.SectionType != e.sectionType || c.SectionName != e.sectionName || c.ObjectType != e.objectType || c.ObjectID != e.objectID {
+			t.Errorf("Change %v is %s %s %s %s %s, expected %v", i, c.Action, c.SectionType, c.SectionName, c.ObjectType, c.ObjectID, e)
+		}
+	}
+
+	if len(DiffStructuredConfigurations(from, from)) != 0 {
+		t.Error("Diff of a configuration with itself is not empty")
+	}
+}
+
+func TestDiffBackendSwitchingRules(t *testing.T) {
+	// both rules switch to the same backend, they are matched by position
+	rules := func(second string) *models.Configuration {
+		return &models.Configuration{
+			Frontends: []*models.ConfigurationFrontend{
+				{
+					Frontend: &models.Frontend{Name: "public"},
+					BackendSwitchingRules: models.BackendSwitchingRules{
+						{Index: misc.Int64P(0), Name: "app", Cond: "if", CondTest: "{ path_beg /api }"},
+						{Index: misc.Int64P(1), Name: "app", Cond: "if", CondTest: second},
+					},
+				},
+			},
+		}
+	}
+	from := rules("{ path_beg /v1 }")
+	to := rules("{ path_beg /v2 }")
+
+	diff := DiffStructuredConfigurations(from, to)
+	if len(diff) != 1 {
+		b, _ := json.Marshal(diff)
+		t.Fatalf("%v changes returned, expected 1: %s", len(diff), b)
+	}
+	c := diff[0]
+	if c.Action != "modified" || c.ObjectType != "backend_switching_rules" || c.ObjectID != "1" {
+		t.Errorf("Change is %s %s %s, expected modified backend_switching_rules 1", c.Action, c.ObjectType, c.ObjectID)
+	}
+	if !reflect.DeepEqual(c.Old, from.Frontends[0].BackendSwitchingRules[1]) {
+		t.Errorf("Old rule %v, expected %v", c.Old, from.Frontends[0].BackendSwitchingRules[1])
+	}
+
+	if len(DiffStructuredConfigurations(from, from)) != 0 {
+		t.Error("Diff of rules with themselves is not empty")
+	}
+}
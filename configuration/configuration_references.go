@@ -0,0 +1,123 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetBackendReferences returns configuration version and the default_backend
+// settings and use_backend rules referencing the backend, deleting a backend
+// that is still referenced breaks the configuration. Returns error on fail or
+// if the backend does not exist.
+func (c *Client) GetBackendReferences(backend string, transactionID string) (int64, models.ConfigurationReferences, error) {
+	v, conf, err := c.GetStructuredConfiguration(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if structuredBackend(conf, backend) == nil {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Backend %s does not exist", backend))
+	}
+	return v, BackendReferences(conf, backend), nil
+}
+
+// GetServerReferences returns configuration version and the servers tracking
+// the server of the backend. Returns error on fail or if the server does not exist.
+func (c *Client) GetServerReferences(name string, backend string, transactionID string) (int64, models.ConfigurationReferences, error) {
+	v, conf, err := c.GetStructuredConfiguration(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+	b := structuredBackend(conf, backend)
+	if b == nil {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Backend %s does not exist", backend))
+	}
+	found := false
+	for _, s := range b.Servers {
+		found = found || s.Name == name
+	}
+	if !found {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Server %s does not exist in backend %s", name, backend))
+	}
+	return v, ServerReferences(conf, name, backend), nil
+}
+
+// BackendReferences returns the defaults and frontends sections using the backend
+// as default_backend and the use_backend rules switching to it. Rules with a
+// dynamic backend name are not resolved and never match.
+func BackendReferences(conf *models.Configuration, backend string) models.ConfigurationReferences {
+	refs := models.ConfigurationReferences{}
+	if conf.Defaults != nil && conf.Defaults.DefaultBackend == backend {
+		refs = append(refs, &models.ConfigurationReference{
+			SectionType: models.ConfigurationReferenceSectionTypeDefaults,
+			ObjectType:  models.ConfigurationReferenceObjectTypeDefaultBackend,
+		})
+	}
+	for _, f := range conf.Frontends {
+		if f.Frontend.DefaultBackend == backend {
+			refs = append(refs, &models.ConfigurationReference{
+				SectionType: models.ConfigurationReferenceSectionTypeFrontend,
+				SectionName: f.Frontend.Name,
+				ObjectType:  models.ConfigurationReferenceObjectTypeDefaultBackend,
+			})
+		}
+		for _, r := range f.BackendSwitchingRules {
+			if r.Name != backend {
+				continue
+			}
+			refs = append(refs, &models.ConfigurationReference{
+				SectionType: models.ConfigurationReferenceSectionTypeFrontend,
+				SectionName: f.Frontend.Name,
+				ObjectType:  models.ConfigurationReferenceObjectTypeBackendSwitchingRule,
+				ObjectID:    strconv.FormatInt(*r.Index, 10),
+			})
+		}
+	}
+	return refs
+}
+
+// ServerReferences returns the servers having "track backend/name", and the
+// servers of the same backend having "track name"
+func ServerReferences(conf *models.Configuration, name string, backend string) models.ConfigurationReferences {
+	refs := models.ConfigurationReferences{}
+	track := backend + "/" + name
+	for _, b := range conf.Backends {
+		for _, s := range b.Servers {
+			if s.Track != track && (s.Track != name || b.Backend.Name != backend) {
+				continue
+			}
+			refs = append(refs, &models.ConfigurationReference{
+				SectionType: models.ConfigurationReferenceSectionTypeBackend,
+				SectionName: b.Backend.Name,
+				ObjectType:  models.ConfigurationReferenceObjectTypeServer,
+				ObjectID:    s.Name,
+			})
+		}
+	}
+	return refs
+}
+
+func structuredBackend(conf *models.Configuration, name string) *models.ConfigurationBackend {
+	for _, b := range conf.Backends {
+		if b.Backend.Name == name {
+			return b
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+const referencesConfig = `# _version=1
+defaults
+  mode http
+
+frontend public
+  bind :80
+  default_backend app
+
+frontend internal
+  bind :8080
+  use_backend static if { path_beg /static }
+  use_backend app if { path_beg /api }
+  use_backend %[req.hdr(host),lower]
+  default_backend static
+
+backend app
+  server app1 10.0.0.1:80 check
+  server app2 10.0.0.2:80 check
+  server app-backup 10.0.0.6:80 track app1 backup
+
+backend static
+  server static1 10.0.0.3:80 track app/app1
+  server static2 10.0.0.4:80 track app/app2
+
+backend unused
+  server unused1 10.0.0.5:80
+`
+
+func TestGetBackendReferences(t *testing.T) {
+	path := "/tmp/haproxy-references.cfg"
+	if err := prepareTestFile(referencesConfig, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	v, refs, err := c.GetBackendReferences("app", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if v != 1 {
+		t.Errorf("Version %v returned, expected 1", v)
+	}
+	expected := models.ConfigurationReferences{
+		{SectionType: "frontend", SectionName: "internal", ObjectType: "backend_switching_rule", ObjectID: "1"},
+		{SectionType: "frontend", SectionName: "public", ObjectType: "default_backend"},
+	}
+	if !reflect.DeepEqual(refs, expected) {
+		b, _ := json.Marshal(refs)
+		t.Errorf("References %s, expected backend app used by frontends internal and public", b)
+	}
+
+	if _, refs, err := c.GetBackendReferences("unused", ""); err != nil || len(refs) != 0 {
+		t.Errorf("References %v returned for an unused backend: %v", refs, err)
+	}
+	if _, _, err := c.GetBackendReferences("doesnotexist", ""); err == nil {
+		t.Error("Should throw error, backend does not exist")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectDoesNotExist {
+		t.Errorf("Expected object does not exist error, got: %v", err)
+	}
+
+	_, refs, err = c.GetServerReferences("app1", "app", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// "track app1" refers to the server of the same backend
+	expected = models.ConfigurationReferences{
+		{SectionType: "backend", SectionName: "app", ObjectType: "server", ObjectID: "app-backup"},
+		{SectionType: "backend", SectionName: "static", ObjectType: "server", ObjectID: "static1"},
+	}
+	if !reflect.DeepEqual(refs, expected) {
+		b, _ := json.Marshal(refs)
+		t.Errorf("References %s, expected server app1 tracked by app-backup and static1", b)
+	}
+	if _, _, err := c.GetServerReferences("app3", "app", ""); err == nil {
+		t.Error("Should throw error, server does not exist")
+	}
+	if _, _, err := c.GetServerReferences("app1", "doesnotexist", ""); err == nil {
+		t.Error("Should throw error, backend does not exist")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected parent does not exist error, got: %v", err)
+	}
+}
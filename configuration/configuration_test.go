@@ -201,6 +201,17 @@ backend test
   http-request set-dst hdr(x-dst)
   http-request set-dst-port int(4000)
 
+cache mycache
+  total-max-size 4
+  max-object-size 524288
+  max-age 240
+
+userlist first
+  group G1 users tiger,scott
+  group G2 users scott
+  user tiger password $6$k6y3o.eP$JlKBx9za9667qe4xHSwRv6J.C0/D7cV91
+  user scott insecure-password elgato groups G1,G2
+
 peers mycluster
   peer hapee 192.168.1.1:1023
   peer aggregator HARDCODEDCLUSTERIP:10023
@@ -47,7 +47,10 @@ func (c *Client) PushDefaultsConfiguration(data *models.Defaults, transactionID
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
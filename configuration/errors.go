@@ -17,10 +17,15 @@ package configuration
 
 import (
 	"fmt"
+	"strings"
 
 	oaerrors "github.com/go-openapi/errors"
 )
 
+// Error codes returned in ConfError. Operations on child objects (binds,
+// servers, rules...) return ErrParentDoesNotExist when the parent section is
+// missing, and ErrObjectDoesNotExist when the parent exists but the requested
+// object does not.
 const (
 	// General error, unknown cause
 	ErrGeneralError = 0
@@ -53,8 +58,9 @@ const (
 
 // ConfError general configuration client error
 type ConfError struct {
-	code int
-	msg  string
+	code      int
+	msg       string
+	subErrors []string
 }
 
 // Error implementation for ConfError
@@ -67,11 +73,43 @@ func (e *ConfError) Code() int {
 	return e.code
 }
 
+// SubErrors returns the field level messages of a validation error, empty
+// for other errors
+func (e *ConfError) SubErrors() []string {
+	return e.subErrors
+}
+
 // NewConfError constructor for ConfError
 func NewConfError(code int, msg string) *ConfError {
 	return &ConfError{code: code, msg: msg}
 }
 
+// NewValidationError constructor for ConfError with ErrValidationError code,
+// every field problem reported by go-openapi validation is kept in SubErrors
+func NewValidationError(err error) *ConfError {
+	subErrors := flattenValidationError(err)
+	msg := err.Error()
+	switch {
+	case len(subErrors) == 1:
+		msg = subErrors[0]
+	case len(subErrors) > 1:
+		msg = "validation failure list: " + strings.Join(subErrors, ", ")
+	}
+	return &ConfError{code: ErrValidationError, msg: msg, subErrors: subErrors}
+}
+
+func flattenValidationError(err error) []string {
+	composite, ok := err.(*oaerrors.CompositeError)
+	if !ok {
+		return []string{err.Error()}
+	}
+	res := []string{}
+	for _, e := range composite.Errors {
+		res = append(res, flattenValidationError(e)...)
+	}
+	return res
+}
+
 // CompositeTransactionError helper function to aggregate multiple errors
 // when calling multiple operations in transactions.
 func CompositeTransactionError(e ...error) *oaerrors.CompositeError {
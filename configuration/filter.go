@@ -17,6 +17,7 @@ package configuration
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/go-openapi/strfmt"
@@ -106,13 +107,22 @@ func (c *Client) DeleteFilter(id int64, parentType string, parentName string, tr
 	return nil
 }
 
-// CreateFilter creates a filter in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateFilter creates a filter in configuration at data.Index, filters at that
+// position and after it are moved down, a nil index appends the filter. One of
+// version or transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateFilter(parentType string, parentName string, data *models.Filter, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		if !c.SkipSemanticValidation {
+			if err := validateFilter(data); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -128,8 +138,19 @@ func (c *Client) CreateFilter(parentType string, parentName string, data *models
 		section = parser.Frontends
 	}
 
-	if err := p.Insert(section, parentName, "filter", SerializeFilter(*data), int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), parentType, parentName, t, transactionID == "", err)
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
+	if c.UseValidation && !c.SkipSemanticValidation {
+		if err := c.validateFilterCache(data, p); err != nil {
+			return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
+		}
+	}
+
+	if err := p.Insert(section, parentName, "filter", SerializeFilter(*data), int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -145,7 +166,15 @@ func (c *Client) EditFilter(id int64, parentType string, parentName string, data
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		if !c.SkipSemanticValidation {
+			if err := validateFilter(data); err != nil {
+				return err
+			}
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -164,6 +193,12 @@ func (c *Client) EditFilter(id int64, parentType string, parentName string, data
 		return c.HandleError(strconv.FormatInt(id, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
+	if c.UseValidation && !c.SkipSemanticValidation {
+		if err := c.validateFilterCache(data, p); err != nil {
+			return c.HandleError(strconv.FormatInt(id, 10), parentType, parentName, t, transactionID == "", err)
+		}
+	}
+
 	if err := p.Set(section, parentName, "filter", SerializeFilter(*data), int(id)); err != nil {
 		return c.HandleError(strconv.FormatInt(id, 10), parentType, parentName, t, transactionID == "", err)
 	}
@@ -258,3 +293,37 @@ func SerializeFilter(f models.Filter) types.Filter {
 	}
 	return nil
 }
+
+// validateFilterCache checks that the cache section used by a cache filter exists.
+func (c *Client) validateFilterCache(data *models.Filter, p *parser.Parser) error {
+	if data.Type == "cache" && data.CacheName != "" && !c.checkSectionExists(parser.Cache, data.CacheName, p) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("cache section %s does not exist", data.CacheName))
+	}
+	return nil
+}
+
+// ValidateFilter checks a filter against the model schema and the fields its
+// type requires, without loading any configuration. Whether a referenced cache
+// section exists is not checked. Returns a ConfError with the ErrValidationError
+// code on fail, nil on success.
+func ValidateFilter(data *models.Filter) error {
+	if err := data.Validate(strfmt.Default); err != nil {
+		return NewValidationError(err)
+	}
+	return validateFilter(data)
+}
+
+// validateFilter checks that the fields required by the filter type are set.
+func validateFilter(data *models.Filter) error {
+	switch data.Type {
+	case "spoe":
+		if data.SpoeConfig == "" {
+			return NewConfError(ErrValidationError, "spoe filter requires spoe_config")
+		}
+	case "cache":
+		if data.CacheName == "" {
+			return NewConfError(ErrValidationError, "cache filter requires cache_name")
+		}
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -202,3 +203,76 @@ func TestCreateEditDeleteFilter(t *testing.T) {
 		version++
 	}
 }
+
+func TestFiltersOrder(t *testing.T) {
+	err := client.CreateBackend(&models.Backend{Name: "filter_order"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	filters := []*models.Filter{
+		{Index: misc.Int64P(0), Type: "compression"},
+		{Index: misc.Int64P(1), Type: "cache", CacheName: "mycache"},
+		// inserted before compression to trace what enters the chain
+		{Index: misc.Int64P(0), Type: "trace", TraceName: "before_compression", TraceHexdump: true},
+	}
+	for _, f := range filters {
+		if err := client.CreateFilter("backend", "filter_order", f, "", version); err != nil {
+			t.Fatalf("%s: %s", f.Type, err.Error())
+		}
+		version++
+	}
+
+	// without validation a filter without index is appended
+	client.UseValidation = false
+	err = client.CreateFilter("backend", "filter_order", &models.Filter{Type: "spoe", SpoeEngine: "agent", SpoeConfig: "/etc/haproxy/spoe.cfg"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetFilters("backend", "filter_order", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []string{"trace", "compression", "cache", "spoe"}
+	if len(created) != len(expected) {
+		t.Fatalf("%v filters returned, expected %v", len(created), len(expected))
+	}
+	for i, f := range created {
+		if *f.Index != int64(i) {
+			t.Errorf("Filter %v has index %v", i, *f.Index)
+		}
+		if f.Type != expected[i] {
+			t.Errorf("Filter %v is %s, expected %s", i, f.Type, expected[i])
+		}
+	}
+	if created[0].TraceName != "before_compression" || !created[0].TraceHexdump {
+		t.Errorf("Trace filter is %v, expected name before_compression with hexdump", *created[0])
+	}
+
+	if err := client.DeleteBackend("filter_order", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestCreateFilterMissingTypeFields(t *testing.T) {
+	for _, f := range []*models.Filter{
+		{Index: misc.Int64P(0), Type: "cache"},
+		{Index: misc.Int64P(0), Type: "spoe", SpoeEngine: "agent"},
+	} {
+		err := client.CreateFilter("frontend", "test", f, "", version)
+		if err == nil {
+			version++
+			t.Errorf("Should throw validation error for %s filter", f.Type)
+			continue
+		}
+		if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+			t.Errorf("Expected validation error for %s filter, got: %v", f.Type, err)
+		}
+	}
+}
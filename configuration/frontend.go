@@ -94,7 +94,10 @@ func (c *Client) EditFrontend(name string, data *models.Frontend, transactionID
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -111,7 +114,10 @@ func (c *Client) CreateFrontend(data *models.Frontend, transactionID string, ver
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -258,5 +258,7 @@ func TestCreateEditDeleteFrontend(t *testing.T) {
 	if err == nil {
 		t.Error("Should throw error, non existant frontend")
 		version++
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrObjectDoesNotExist {
+		t.Errorf("Should throw ErrObjectDoesNotExist error, got: %v", err)
 	}
 }
@@ -56,7 +56,10 @@ func (c *Client) PushGlobalConfiguration(data *models.Global, transactionID stri
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -66,7 +69,7 @@ func (c *Client) PushGlobalConfiguration(data *models.Global, transactionID stri
 	}
 
 	if err := SerializeGlobalSection(p, data); err != nil {
-		return err
+		return c.HandleError("", "", "", t, transactionID == "", err)
 	}
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
 		return err
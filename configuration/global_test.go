@@ -20,6 +20,8 @@ import (
 	"reflect"
 	"testing"
 
+	parser "github.com/haproxytech/config-parser/v3"
+
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -138,3 +140,73 @@ func TestPutGlobal(t *testing.T) {
 		t.Error("Should have returned version conflict.")
 	}
 }
+
+func TestGlobalRoundTrip(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+global
+  daemon
+  maxconn 50000
+  nbproc 1
+  nbthread 8
+  ssl-default-bind-ciphers ECDHE-RSA-AES128-GCM-SHA256:ECDHE-RSA-AES256-GCM-SHA384
+  ssl-default-bind-ciphersuites TLS_AES_128_GCM_SHA256:TLS_AES_256_GCM_SHA384
+  ssl-default-bind-options ssl-min-ver TLSv1.2 no-tls-tickets
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	parsed, err := ParseGlobalSection(p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := &models.Global{
+		Daemon:                     "enabled",
+		Maxconn:                    50000,
+		Nbproc:                     1,
+		Nbthread:                   8,
+		SslDefaultBindCiphers:      "ECDHE-RSA-AES128-GCM-SHA256:ECDHE-RSA-AES256-GCM-SHA384",
+		SslDefaultBindCiphersuites: "TLS_AES_128_GCM_SHA256:TLS_AES_256_GCM_SHA384",
+		SslDefaultBindOptions:      "ssl-min-ver TLSv1.2 no-tls-tickets",
+	}
+	for name, v := range map[string][2]interface{}{
+		"daemon":                        {parsed.Daemon, expected.Daemon},
+		"maxconn":                       {parsed.Maxconn, expected.Maxconn},
+		"nbproc":                        {parsed.Nbproc, expected.Nbproc},
+		"nbthread":                      {parsed.Nbthread, expected.Nbthread},
+		"ssl-default-bind-ciphers":      {parsed.SslDefaultBindCiphers, expected.SslDefaultBindCiphers},
+		"ssl-default-bind-ciphersuites": {parsed.SslDefaultBindCiphersuites, expected.SslDefaultBindCiphersuites},
+		"ssl-default-bind-options":      {parsed.SslDefaultBindOptions, expected.SslDefaultBindOptions},
+	} {
+		if v[0] != v[1] {
+			t.Errorf("%s is %v, expected %v", name, v[0], v[1])
+		}
+	}
+
+	path := "/tmp/haproxy-global.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, _, err := c.GetGlobalConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.PushGlobalConfiguration(parsed, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+	_, pushed, err := c.GetGlobalConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(pushed, parsed) {
+		t.Errorf("Pushed global %v not equal to given global %v", *pushed, *parsed)
+	}
+
+	invalid := *pushed
+	invalid.Nbthread = -1
+	err = c.PushGlobalConfiguration(&invalid, "", v)
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+}
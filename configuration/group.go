@@ -0,0 +1,228 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/common"
+	parser_errors "github.com/haproxytech/config-parser/v3/errors"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetGroups returns configuration version and an array of
+// configured groups in the specified userlist. Returns error on fail.
+func (c *Client) GetGroups(userlist string, transactionID string) (int64, models.Groups, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	groups, err := ParseGroups(userlist, p)
+	if err != nil {
+		return v, nil, c.HandleError("", "userlist", userlist, "", false, err)
+	}
+
+	return v, groups, nil
+}
+
+// GetGroup returns configuration version and a requested group
+// in the specified userlist. Returns error on fail or if group does not exist.
+func (c *Client) GetGroup(name string, userlist string, transactionID string) (int64, *models.Group, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+	}
+
+	group, _ := GetGroupByName(name, userlist, p)
+	if group == nil {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Group %s does not exist in userlist %s", name, userlist))
+	}
+
+	return v, group, nil
+}
+
+// DeleteGroup deletes a group in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteGroup(name string, userlist string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+		return c.HandleError(name, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	group, i := GetGroupByName(name, userlist, p)
+	if group == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Group %s does not exist in userlist %s", name, userlist))
+		return c.HandleError(name, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	if err := p.Delete(parser.UserList, userlist, "group", i); err != nil {
+		return c.HandleError(name, "userlist", userlist, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateGroup creates a group in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateGroup(userlist string, data *models.Group, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+		return c.HandleError(data.Name, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	group, _ := GetGroupByName(data.Name, userlist, p)
+	if group != nil {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Group %s already exists in userlist %s", data.Name, userlist))
+		return c.HandleError(data.Name, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	if err := p.Insert(parser.UserList, userlist, "group", SerializeGroup(*data), -1); err != nil {
+		return c.HandleError(data.Name, "userlist", userlist, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditGroup edits a group in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditGroup(name string, userlist string, data *models.Group, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+		return c.HandleError(data.Name, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	group, i := GetGroupByName(name, userlist, p)
+	if group == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Group %s does not exist in userlist %s", name, userlist))
+		return c.HandleError(data.Name, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	if err := p.Set(parser.UserList, userlist, "group", SerializeGroup(*data), i); err != nil {
+		return c.HandleError(data.Name, "userlist", userlist, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ParseGroups(userlist string, p *parser.Parser) (models.Groups, error) {
+	groups := models.Groups{}
+
+	data, err := p.Get(parser.UserList, userlist, "group", false)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrFetch) {
+			return groups, nil
+		}
+		return nil, err
+	}
+
+	for _, g := range data.([]types.Group) {
+		groups = append(groups, ParseGroup(g))
+	}
+	return groups, nil
+}
+
+func ParseGroup(g types.Group) *models.Group {
+	return &models.Group{
+		Name:  g.Name,
+		Users: strings.Join(g.Users, ","),
+	}
+}
+
+func SerializeGroup(g models.Group) types.Group {
+	return types.Group{
+		Name:  g.Name,
+		Users: common.StringSplitIgnoreEmpty(g.Users, ','),
+	}
+}
+
+func GetGroupByName(name string, userlist string, p *parser.Parser) (*models.Group, int) {
+	groups, err := ParseGroups(userlist, p)
+	if err != nil {
+		return nil, 0
+	}
+
+	for i, g := range groups {
+		if g.Name == name {
+			return g, i
+		}
+	}
+	return nil, 0
+}
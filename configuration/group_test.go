@@ -0,0 +1,122 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestGetGroups(t *testing.T) {
+	v, groups, err := client.GetGroups("first", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if len(groups) != 2 {
+		t.Errorf("%v groups returned, expected 2", len(groups))
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	for _, g := range groups {
+		switch g.Name {
+		case "G1":
+			if g.Users != "tiger,scott" {
+				t.Errorf("%v: Users not tiger,scott: %v", g.Name, g.Users)
+			}
+		case "G2":
+			if g.Users != "scott" {
+				t.Errorf("%v: Users not scott: %v", g.Name, g.Users)
+			}
+		default:
+			t.Errorf("Expected only G1 or G2, %v found", g.Name)
+		}
+	}
+}
+
+func TestCreateEditDeleteGroup(t *testing.T) {
+	g := &models.Group{Name: "G3", Users: "tiger"}
+
+	err := client.CreateGroup("first", g, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	v, group, err := client.GetGroup("G3", "first", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(g, group) {
+		t.Errorf("Created group %v not equal to given group %v", group, g)
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	err = client.CreateGroup("first", g, "", version)
+	if err == nil {
+		t.Error("Should throw error group already exists")
+		version++
+	}
+
+	g = &models.Group{Name: "G3", Users: "tiger,scott"}
+	err = client.EditGroup("G3", "first", g, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, group, err = client.GetGroup("G3", "first", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(g, group) {
+		t.Errorf("Edited group %v not equal to given group %v", group, g)
+	}
+
+	err = client.DeleteGroup("G3", "first", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetGroup("G3", "first", "")
+	if err == nil {
+		t.Error("DeleteGroup failed, group G3 still exists")
+	}
+
+	err = client.DeleteGroup("G3", "doesnotexist", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existant userlist")
+		version++
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	parser_errors "github.com/haproxytech/config-parser/v3/errors"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetHTTPErrorsSections returns configuration version and an array of
+// configured http-errors sections. Returns error on fail.
+func (c *Client) GetHTTPErrorsSections(transactionID string) (int64, models.HTTPErrorsSections, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	names, err := p.SectionsGet(parser.HTTPErrors)
+	if err != nil {
+		return v, nil, err
+	}
+
+	sections := models.HTTPErrorsSections{}
+	for _, name := range names {
+		section, err := ParseHTTPErrorsSection(p, name)
+		if err != nil {
+			return v, nil, err
+		}
+		sections = append(sections, section)
+	}
+
+	return v, sections, nil
+}
+
+// GetHTTPErrorsSection returns configuration version and a requested http-errors section.
+// Returns error on fail or if http-errors section does not exist.
+func (c *Client) GetHTTPErrorsSection(name string, transactionID string) (int64, *models.HTTPErrorsSection, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.HTTPErrors, name, p) {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("http-errors section %s does not exist", name))
+	}
+
+	section, err := ParseHTTPErrorsSection(p, name)
+	if err != nil {
+		return v, nil, err
+	}
+
+	return v, section, nil
+}
+
+// DeleteHTTPErrorsSection deletes an http-errors section in configuration. One of version or
+// transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteHTTPErrorsSection(name string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.HTTPErrors, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.HTTPErrors, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsDelete(parser.HTTPErrors, name); err != nil {
+		return c.HandleError(name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateHTTPErrorsSection creates an http-errors section in configuration. One of version or
+// transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateHTTPErrorsSection(data *models.HTTPErrorsSection, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if err := p.SectionsCreate(parser.HTTPErrors, data.Name); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := SerializeHTTPErrorsSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditHTTPErrorsSection replaces the error files of an http-errors section in configuration.
+// One of version or transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) EditHTTPErrorsSection(name string, data *models.HTTPErrorsSection, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.HTTPErrors, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.HTTPErrors, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := SerializeHTTPErrorsSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseHTTPErrorsSection reads the errorfile lines of an http-errors section.
+// config-parser has no parsers for this section, so its lines are read from
+// the unprocessed ones.
+func ParseHTTPErrorsSection(p *parser.Parser, name string) (*models.HTTPErrorsSection, error) {
+	section := &models.HTTPErrorsSection{Name: name, ErrorFiles: []*models.Errorfile{}}
+
+	data, err := p.Get(parser.HTTPErrors, name, "", false)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrFetch) {
+			return section, nil
+		}
+		return nil, err
+	}
+
+	for _, line := range data.([]types.UnProcessed) {
+		parts := strings.Fields(line.Value)
+		if len(parts) < 3 || parts[0] != "errorfile" {
+			continue
+		}
+		code, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		section.ErrorFiles = append(section.ErrorFiles, &models.Errorfile{Code: code, File: parts[2]})
+	}
+	return section, nil
+}
+
+// SerializeHTTPErrorsSection replaces the errorfile lines of an existing
+// http-errors section, other lines of the section are kept.
+func SerializeHTTPErrorsSection(p *parser.Parser, data *models.HTTPErrorsSection) error {
+	lines := []types.UnProcessed{}
+	current, err := p.Get(parser.HTTPErrors, data.Name, "", false)
+	if err != nil && !errors.Is(err, parser_errors.ErrFetch) {
+		return err
+	}
+	if err == nil {
+		for _, line := range current.([]types.UnProcessed) {
+			if !strings.HasPrefix(line.Value, "errorfile ") {
+				lines = append(lines, line)
+			}
+		}
+	}
+	for _, ef := range data.ErrorFiles {
+		if ef == nil {
+			continue
+		}
+		lines = append(lines, types.UnProcessed{Value: fmt.Sprintf("errorfile %d %s", ef.Code, ef.File)})
+	}
+	return p.Set(parser.HTTPErrors, data.Name, "", lines)
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestCreateEditDeleteHTTPErrorsSection(t *testing.T) {
+	s := &models.HTTPErrorsSection{
+		Name: "myerrors",
+		ErrorFiles: []*models.Errorfile{
+			{Code: 503, File: "/etc/haproxy/errorfiles/503sorry.http"},
+			{Code: 403, File: "/etc/haproxy/errorfiles/403forbid.http"},
+		},
+	}
+	err := client.CreateHTTPErrorsSection(s, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	v, section, err := client.GetHTTPErrorsSection("myerrors", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(s, section) {
+		t.Errorf("Created http-errors section %v not equal to given section %v", section, s)
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	err = client.CreateHTTPErrorsSection(s, "", version)
+	if err == nil {
+		t.Error("Should throw error http-errors section already exists")
+		version++
+	}
+
+	_, sections, err := client.GetHTTPErrorsSections("")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(sections) != 1 {
+		t.Errorf("%v http-errors sections returned, expected 1", len(sections))
+	}
+
+	// reference both codes from a frontend next to a plain errorfile
+	f := &models.Frontend{
+		Name: "errors",
+		Mode: "http",
+		ErrorFiles: []*models.Errorfile{
+			{Code: 400, File: "/etc/haproxy/errorfiles/400badreq.http"},
+		},
+		ErrorFilesFromHTTPErrors: []*models.Errorfiles{
+			{Name: "myerrors", Codes: []int64{503, 403}},
+		},
+	}
+	err = client.CreateFrontend(f, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, frontend, err := client.GetFrontend("errors", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(f.ErrorFiles, frontend.ErrorFiles) {
+		t.Errorf("Frontend error files %v, expected %v", frontend.ErrorFiles, f.ErrorFiles)
+	}
+	if !reflect.DeepEqual(f.ErrorFilesFromHTTPErrors, frontend.ErrorFilesFromHTTPErrors) {
+		t.Errorf("Frontend errorfiles %v, expected %v", frontend.ErrorFilesFromHTTPErrors, f.ErrorFilesFromHTTPErrors)
+	}
+
+	f.ErrorFilesFromHTTPErrors = nil
+	err = client.EditFrontend("errors", f, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+	_, frontend, err = client.GetFrontend("errors", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if frontend.ErrorFilesFromHTTPErrors != nil {
+		t.Errorf("Frontend errorfiles %v not removed", frontend.ErrorFilesFromHTTPErrors)
+	}
+
+	err = client.DeleteFrontend("errors", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	s = &models.HTTPErrorsSection{
+		Name: "myerrors",
+		ErrorFiles: []*models.Errorfile{
+			{Code: 503, File: "/etc/haproxy/errorfiles/503maintenance.http"},
+		},
+	}
+	err = client.EditHTTPErrorsSection("myerrors", s, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, section, err = client.GetHTTPErrorsSection("myerrors", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(s, section) {
+		t.Errorf("Edited http-errors section %v not equal to given section %v", section, s)
+	}
+
+	err = client.DeleteHTTPErrorsSection("myerrors", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetHTTPErrorsSection("myerrors", "")
+	if err == nil {
+		t.Error("DeleteHTTPErrorsSection failed, http-errors section myerrors still exists")
+	}
+
+	err = client.DeleteHTTPErrorsSection("myerrors", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existant http-errors section")
+		version++
+	}
+}
@@ -110,13 +110,17 @@ func (c *Client) DeleteHTTPRequestRule(id int64, parentType string, parentName s
 	return nil
 }
 
-// CreateHTTPRequestRule creates a http request rule in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateHTTPRequestRule creates a http request rule in configuration at data.Index, rules at
+// that position and after it are moved down, a nil index appends the rule. One of version
+// or transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateHTTPRequestRule(parentType string, parentName string, data *models.HTTPRequestRule, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -132,13 +136,18 @@ func (c *Client) CreateHTTPRequestRule(parentType string, parentName string, dat
 		section = parser.Frontends
 	}
 
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
 	s, err := SerializeHTTPRequestRule(*data)
 	if err != nil {
-		return err
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
-	if err := p.Insert(section, parentName, "http-request", s, int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), parentType, parentName, t, transactionID == "", err)
+	if err := p.Insert(section, parentName, "http-request", s, int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -154,7 +163,10 @@ func (c *Client) EditHTTPRequestRule(id int64, parentType string, parentName str
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -175,7 +187,7 @@ func (c *Client) EditHTTPRequestRule(id int64, parentType string, parentName str
 
 	s, err := SerializeHTTPRequestRule(*data)
 	if err != nil {
-		return err
+		return c.HandleError(strconv.FormatInt(id, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := p.Set(section, parentName, "http-request", s, int(id)); err != nil {
@@ -227,8 +239,7 @@ func ParseHTTPRequestRule(f types.HTTPAction) (rule *models.HTTPRequestRule, err
 		}
 	case *actions.Deny:
 		var denyPtr *int64
-		var ds int64
-		if ds, err = strconv.ParseInt(v.DenyStatus, 10, 64); err == nil {
+		if ds, errParse := strconv.ParseInt(v.DenyStatus, 10, 64); errParse == nil {
 			denyPtr = &ds
 		}
 		rule = &models.HTTPRequestRule{
@@ -246,8 +257,7 @@ func ParseHTTPRequestRule(f types.HTTPAction) (rule *models.HTTPRequestRule, err
 		}
 	case *actions.Redirect:
 		var codePtr *int64
-		var code int64
-		if code, err = strconv.ParseInt(v.Code, 10, 64); err == nil {
+		if code, errParse := strconv.ParseInt(v.Code, 10, 64); errParse == nil {
 			codePtr = &code
 		}
 		rule = &models.HTTPRequestRule{
@@ -262,8 +272,7 @@ func ParseHTTPRequestRule(f types.HTTPAction) (rule *models.HTTPRequestRule, err
 
 	case *actions.Tarpit:
 		var dsPtr *int64
-		var ds int64
-		if ds, err = strconv.ParseInt(v.DenyStatus, 10, 64); err == nil {
+		if ds, errParse := strconv.ParseInt(v.DenyStatus, 10, 64); errParse == nil {
 			dsPtr = &ds
 		}
 		rule = &models.HTTPRequestRule{
@@ -942,13 +951,17 @@ func SerializeHTTPRequestRule(f models.HTTPRequestRule) (rule types.HTTPAction,
 		if len(f.ScExpr) == 0 && f.ScInt == nil {
 			return nil, NewConfError(ErrValidationError, "sc-set-gpt0 int or expr has to be set")
 		}
-		rule = &actions.ScSetGpt0{
+		a := &actions.ScSetGpt0{
 			ID:       strconv.FormatInt(f.ScID, 10),
 			Int:      f.ScInt,
-			Expr:     common.Expression{Expr: strings.Split(f.ScExpr, " ")},
 			Cond:     f.Cond,
 			CondTest: f.CondTest,
 		}
+		// an empty expression would be written next to the int value
+		if len(f.ScExpr) > 0 {
+			a.Expr = common.Expression{Expr: strings.Split(f.ScExpr, " ")}
+		}
+		rule = a
 	case "set-mark":
 		rule = &actions.SetMark{
 			Value:    f.MarkValue,
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -638,3 +639,67 @@ func TestCreateEditDeleteHTTPRequestRule(t *testing.T) {
 		version++
 	}
 }
+
+func TestHTTPRequestRulesOrder(t *testing.T) {
+	err := client.CreateFrontend(&models.Frontend{Name: "http_request_order"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	rules := []*models.HTTPRequestRule{
+		{Index: misc.Int64P(0), Type: "deny", Cond: "if", CondTest: "{ src 10.0.0.0/8 }"},
+		{Index: misc.Int64P(0), Type: "allow", Cond: "unless", CondTest: "{ path_beg /admin }"},
+		{Index: misc.Int64P(2), Type: "redirect", RedirType: "scheme", RedirValue: "https", Cond: "if", CondTest: "!{ ssl_fc }"},
+		{Index: misc.Int64P(3), Type: "set-header", HdrName: "X-Forwarded-Proto", HdrFormat: "https"},
+		{Index: misc.Int64P(2), Type: "add-header", HdrName: "X-Request-Start", HdrFormat: "%t"},
+	}
+	for _, r := range rules {
+		if err := client.CreateHTTPRequestRule("frontend", "http_request_order", r, "", version); err != nil {
+			t.Fatalf("%s: %s", r.Type, err.Error())
+		}
+		version++
+	}
+
+	// without validation a rule without index is appended
+	client.UseValidation = false
+	err = client.CreateHTTPRequestRule("frontend", "http_request_order", &models.HTTPRequestRule{Type: "del-header", HdrName: "X-Debug"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetHTTPRequestRules("frontend", "http_request_order", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []struct {
+		ruleType string
+		cond     string
+		condTest string
+	}{
+		{"allow", "unless", "{ path_beg /admin }"},
+		{"deny", "if", "{ src 10.0.0.0/8 }"},
+		{"add-header", "", ""},
+		{"redirect", "if", "!{ ssl_fc }"},
+		{"set-header", "", ""},
+		{"del-header", "", ""},
+	}
+	if len(created) != len(expected) {
+		t.Fatalf("%v http request rules returned, expected %v", len(created), len(expected))
+	}
+	for i, e := range expected {
+		r := created[i]
+		if r.Type != e.ruleType || r.Cond != e.cond || r.CondTest != e.condTest || *r.Index != int64(i) {
+			t.Errorf("Rule %v is %v %s %s %s, expected %s %s %s", i, *r.Index, r.Type, r.Cond, r.CondTest, e.ruleType, e.cond, e.condTest)
+		}
+	}
+
+	err = client.DeleteFrontend("http_request_order", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
@@ -108,13 +108,17 @@ func (c *Client) DeleteHTTPResponseRule(id int64, parentType string, parentName
 	return nil
 }
 
-// CreateHTTPResponseRule creates a http response rule in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateHTTPResponseRule creates a http response rule in configuration at data.Index, rules at
+// that position and after it are moved down, a nil index appends the rule. One of version
+// or transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateHTTPResponseRule(parentType string, parentName string, data *models.HTTPResponseRule, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -129,8 +133,13 @@ func (c *Client) CreateHTTPResponseRule(parentType string, parentName string, da
 		section = parser.Frontends
 	}
 
-	if err := p.Insert(section, parentName, "http-response", SerializeHTTPResponseRule(*data), int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), parentType, parentName, t, transactionID == "", err)
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
+	if err := p.Insert(section, parentName, "http-response", SerializeHTTPResponseRule(*data), int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -146,7 +155,10 @@ func (c *Client) EditHTTPResponseRule(id int64, parentType string, parentName st
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -601,13 +613,17 @@ func SerializeHTTPResponseRule(f models.HTTPResponseRule) types.HTTPAction { //n
 		if (len(f.ScExpr) > 0 && f.ScInt != nil) || (len(f.ScExpr) == 0 && f.ScInt == nil) {
 			return nil
 		}
-		return &actions.ScSetGpt0{
+		a := &actions.ScSetGpt0{
 			ID:       strconv.FormatInt(f.ScID, 10),
 			Int:      f.ScInt,
-			Expr:     common.Expression{Expr: strings.Split(f.ScExpr, " ")},
 			Cond:     f.Cond,
 			CondTest: f.CondTest,
 		}
+		// an empty expression would be written next to the int value
+		if len(f.ScExpr) > 0 {
+			a.Expr = common.Expression{Expr: strings.Split(f.ScExpr, " ")}
+		}
+		return a
 	case "set-mark":
 		return &actions.SetMark{
 			Value:    f.MarkValue,
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -453,3 +454,64 @@ func TestCreateEditDeleteHTTPResponseRule(t *testing.T) {
 		version++
 	}
 }
+
+func TestHTTPResponseRulesInsertAtIndex(t *testing.T) {
+	err := client.CreateBackend(&models.Backend{Name: "http_response_order"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	rules := []*models.HTTPResponseRule{
+		{Index: misc.Int64P(0), Type: "set-header", HdrName: "Strict-Transport-Security", HdrFormat: "max-age=31536000"},
+		{Index: misc.Int64P(1), Type: "del-header", HdrName: "Server"},
+		{Index: misc.Int64P(2), Type: "deny", Cond: "if", CondTest: "{ status 500 } !{ path_beg /health }"},
+		{Index: misc.Int64P(3), Type: "allow", Cond: "unless", CondTest: "{ res.hdr(X-Blocked) -m found }"},
+		// inserted between del-header and deny
+		{Index: misc.Int64P(2), Type: "set-status", Status: 503, StatusReason: "\"Service Unavailable\"", Cond: "if", CondTest: "{ status 500 }"},
+		// inserted first
+		{Index: misc.Int64P(0), Type: "add-header", HdrName: "X-Served-By", HdrFormat: "%s"},
+	}
+	for _, r := range rules {
+		if err := client.CreateHTTPResponseRule("backend", "http_response_order", r, "", version); err != nil {
+			t.Fatalf("%s: %s", r.Type, err.Error())
+		}
+		version++
+	}
+
+	_, created, err := client.GetHTTPResponseRules("backend", "http_response_order", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []struct {
+		ruleType string
+		cond     string
+		condTest string
+	}{
+		{"add-header", "", ""},
+		{"set-header", "", ""},
+		{"del-header", "", ""},
+		{"set-status", "if", "{ status 500 }"},
+		{"deny", "if", "{ status 500 } !{ path_beg /health }"},
+		{"allow", "unless", "{ res.hdr(X-Blocked) -m found }"},
+	}
+	if len(created) != len(expected) {
+		t.Fatalf("%v http response rules returned, expected %v", len(created), len(expected))
+	}
+	for i, e := range expected {
+		r := created[i]
+		if r.Type != e.ruleType || r.Cond != e.cond || r.CondTest != e.condTest || *r.Index != int64(i) {
+			t.Errorf("Rule %v is %v %s %s %s, expected %s %s %s", i, *r.Index, r.Type, r.Cond, r.CondTest, e.ruleType, e.cond, e.condTest)
+		}
+	}
+	if created[3].Status != 503 {
+		t.Errorf("set-status %v returned, expected 503", created[3].Status)
+	}
+
+	err = client.DeleteBackend("http_response_order", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
@@ -28,7 +28,8 @@ import (
 )
 
 // GetLogTargets returns configuration version and an array of
-// configured log targets in the specified parent. Returns error on fail.
+// configured log targets in the specified parent, parentType is one of
+// global, frontend or backend. Returns error on fail.
 func (c *Client) GetLogTargets(parentType, parentName string, transactionID string) (int64, models.LogTargets, error) {
 	p, err := c.GetParser(transactionID)
 	if err != nil {
@@ -61,12 +62,7 @@ func (c *Client) GetLogTarget(id int64, parentType, parentName string, transacti
 		return 0, nil, err
 	}
 
-	var section parser.Section
-	if parentType == "backend" {
-		section = parser.Backends
-	} else if parentType == "frontend" {
-		section = parser.Frontends
-	}
+	section, parentName := logTargetSection(parentType, parentName)
 
 	data, err := p.GetOne(section, parentName, "log", int(id))
 	if err != nil {
@@ -87,12 +83,7 @@ func (c *Client) DeleteLogTarget(id int64, parentType string, parentName string,
 		return err
 	}
 
-	var section parser.Section
-	if parentType == "backend" {
-		section = parser.Backends
-	} else if parentType == "frontend" {
-		section = parser.Frontends
-	}
+	section, parentName := logTargetSection(parentType, parentName)
 
 	if err := p.Delete(section, parentName, "log", int(id)); err != nil {
 		return c.HandleError(strconv.FormatInt(id, 10), parentType, parentName, t, transactionID == "", err)
@@ -105,13 +96,17 @@ func (c *Client) DeleteLogTarget(id int64, parentType string, parentName string,
 	return nil
 }
 
-// CreateLogTarget creates a log target in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateLogTarget creates a log target in configuration at data.Index, log targets at that
+// position and after it are moved down, a nil index appends the log target. One of version
+// or transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateLogTarget(parentType string, parentName string, data *models.LogTarget, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -120,15 +115,14 @@ func (c *Client) CreateLogTarget(parentType string, parentName string, data *mod
 		return err
 	}
 
-	var section parser.Section
-	if parentType == "backend" {
-		section = parser.Backends
-	} else if parentType == "frontend" {
-		section = parser.Frontends
-	}
+	section, parentName := logTargetSection(parentType, parentName)
 
-	if err := p.Insert(section, parentName, "log", SerializeLogTarget(*data), int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), parentType, parentName, t, transactionID == "", err)
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+	if err := p.Insert(section, parentName, "log", SerializeLogTarget(*data), int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -144,7 +138,10 @@ func (c *Client) EditLogTarget(id int64, parentType string, parentName string, d
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -152,12 +149,7 @@ func (c *Client) EditLogTarget(id int64, parentType string, parentName string, d
 		return err
 	}
 
-	var section parser.Section
-	if parentType == "backend" {
-		section = parser.Backends
-	} else if parentType == "frontend" {
-		section = parser.Frontends
-	}
+	section, parentName := logTargetSection(parentType, parentName)
 
 	if _, err := p.GetOne(section, parentName, "log", int(id)); err != nil {
 		return c.HandleError(strconv.FormatInt(id, 10), parentType, parentName, t, transactionID == "", err)
@@ -174,12 +166,7 @@ func (c *Client) EditLogTarget(id int64, parentType string, parentName string, d
 }
 
 func ParseLogTargets(t, pName string, p *parser.Parser) (models.LogTargets, error) {
-	var section parser.Section
-	if t == "backend" {
-		section = parser.Backends
-	} else if t == "frontend" {
-		section = parser.Frontends
-	}
+	section, pName := logTargetSection(t, pName)
 
 	logTargets := models.LogTargets{}
 	data, err := p.Get(section, pName, "log", false)
@@ -202,6 +189,20 @@ func ParseLogTargets(t, pName string, p *parser.Parser) (models.LogTargets, erro
 	return logTargets, nil
 }
 
+// logTargetSection returns the parser section and section name holding the log targets
+// of the parent, the name is ignored for the global parent type
+func logTargetSection(parentType, parentName string) (parser.Section, string) {
+	switch parentType {
+	case "backend":
+		return parser.Backends, parentName
+	case "frontend":
+		return parser.Frontends, parentName
+	case "global":
+		return parser.Global, parser.GlobalSectionName
+	}
+	return "", parentName
+}
+
 func ParseLogTarget(l types.Log) *models.LogTarget {
 	return &models.LogTarget{
 		Address:  l.Address,
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -198,3 +199,110 @@ func TestCreateEditDeleteLogTarget(t *testing.T) {
 		version++
 	}
 }
+
+func TestGlobalLogTargets(t *testing.T) {
+	_, targets, err := client.GetLogTargets("global", "", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	existing := int64(len(targets))
+
+	r := &models.LogTarget{
+		Index:    misc.Int64P(int(existing)),
+		Address:  "127.0.0.1:514",
+		Facility: "local0",
+		Level:    "info",
+	}
+	if err := client.CreateLogTarget("global", "", r, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	// without validation a log target without index is appended
+	client.UseValidation = false
+	err = client.CreateLogTarget("global", "", &models.LogTarget{Address: "/dev/log", Facility: "local1", Format: "rfc5424"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, targets, err = client.GetLogTargets("global", "", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if int64(len(targets)) != existing+2 {
+		t.Fatalf("%v global log targets returned, expected %v", len(targets), existing+2)
+	}
+	if !reflect.DeepEqual(targets[existing], r) {
+		t.Errorf("Global log target %v not equal to given log target %v", *targets[existing], *r)
+	}
+	if targets[existing+1].Address != "/dev/log" || targets[existing+1].Format != "rfc5424" {
+		t.Errorf("Appended global log target is %v, expected /dev/log", *targets[existing+1])
+	}
+
+	// global section edits leave the log targets alone
+	_, global, err := client.GetGlobalConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := client.PushGlobalConfiguration(global, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	r.Level = "notice"
+	if err := client.EditLogTarget(existing, "global", "", r, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+	_, edited, err := client.GetLogTarget(existing, "global", "", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(edited, r) {
+		t.Errorf("Edited global log target %v not equal to given log target %v", *edited, *r)
+	}
+
+	// frontends share the code path
+	_, frontendTargets, err := client.GetLogTargets("frontend", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	client.UseValidation = false
+	err = client.CreateLogTarget("frontend", "test", &models.LogTarget{Address: "stdout", Format: "raw", Facility: "daemon"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+	_, appended, err := client.GetLogTarget(int64(len(frontendTargets)), "frontend", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if appended.Address != "stdout" {
+		t.Errorf("Appended frontend log target is %v, expected stdout", *appended)
+	}
+
+	for _, del := range []struct {
+		parentType, parentName string
+		id                     int64
+	}{
+		{"frontend", "test", int64(len(frontendTargets))},
+		{"global", "", existing + 1},
+		{"global", "", existing},
+	} {
+		if err := client.DeleteLogTarget(del.id, del.parentType, del.parentName, "", version); err != nil {
+			t.Error(err.Error())
+		} else {
+			version++
+		}
+	}
+	_, targets, err = client.GetLogTargets("global", "", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if int64(len(targets)) != existing {
+		t.Errorf("%v global log targets left, expected %v", len(targets), existing)
+	}
+}
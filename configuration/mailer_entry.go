@@ -0,0 +1,330 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	parser_errors "github.com/haproxytech/config-parser/v3/errors"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetMailerEntries returns configuration version and an array of
+// configured mailer entries in the specified mailers section. Returns error on fail.
+func (c *Client) GetMailerEntries(mailersSection string, transactionID string) (int64, models.MailerEntries, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	mailerEntries, err := ParseMailerEntries(mailersSection, p)
+	if err != nil {
+		return v, nil, c.HandleError("", "mailers", mailersSection, "", false, err)
+	}
+
+	return v, mailerEntries, nil
+}
+
+// GetMailerEntry returns configuration version and a requested mailer entry
+// in the specified mailers section. Returns error on fail or if mailer entry does not exist.
+func (c *Client) GetMailerEntry(name string, mailersSection string, transactionID string) (int64, *models.MailerEntry, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Mailers, mailersSection, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Mailers section %s does not exist", mailersSection))
+	}
+
+	mailerEntry, _ := GetMailerEntryByName(name, mailersSection, p)
+	if mailerEntry == nil {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("MailerEntry %s does not exist in mailers section %s", name, mailersSection))
+	}
+
+	return v, mailerEntry, nil
+}
+
+// DeleteMailerEntry deletes a mailer entry in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteMailerEntry(name string, mailersSection string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Mailers, mailersSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Mailers section %s does not exist", mailersSection))
+		return c.HandleError(name, "mailers", mailersSection, t, transactionID == "", e)
+	}
+
+	line := getMailerLine(name, mailersSection, p)
+	if line == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("MailerEntry %s does not exist in mailers section %s", name, mailersSection))
+		return c.HandleError(name, "mailers", mailersSection, t, transactionID == "", e)
+	}
+
+	if err := deleteMailerLine(mailersSection, line, p); err != nil {
+		return c.HandleError(name, "mailers", mailersSection, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateMailerEntry creates a mailer entry in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateMailerEntry(mailersSection string, data *models.MailerEntry, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Mailers, mailersSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Mailers section %s does not exist", mailersSection))
+		return c.HandleError(data.Name, "mailers", mailersSection, t, transactionID == "", e)
+	}
+
+	mailerEntry, _ := GetMailerEntryByName(data.Name, mailersSection, p)
+	if mailerEntry != nil {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("MailerEntry %s already exists in mailers section %s", data.Name, mailersSection))
+		return c.HandleError(data.Name, "mailers", mailersSection, t, transactionID == "", e)
+	}
+
+	if err := insertMailerLine(mailersSection, data, p); err != nil {
+		return c.HandleError(data.Name, "mailers", mailersSection, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditMailerEntry edits a mailer entry in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditMailerEntry(name string, mailersSection string, data *models.MailerEntry, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Mailers, mailersSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Mailers section %s does not exist", mailersSection))
+		return c.HandleError(data.Name, "mailers", mailersSection, t, transactionID == "", e)
+	}
+
+	line := getMailerLine(name, mailersSection, p)
+	if line == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("MailerEntry %v does not exist in mailers section %s", name, mailersSection))
+		return c.HandleError(data.Name, "mailers", mailersSection, t, transactionID == "", e)
+	}
+
+	if !line.unprocessed && !isIPv6Address(*data.Address) {
+		err = p.Set(parser.Mailers, mailersSection, "mailer", SerializeMailerEntry(*data), line.index)
+	} else if err = deleteMailerLine(mailersSection, line, p); err == nil {
+		err = insertMailerLine(mailersSection, data, p)
+	}
+	if err != nil {
+		return c.HandleError(data.Name, "mailers", mailersSection, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// mailerLine locates a mailer entry in its section. config-parser splits the
+// mailer address on every colon, so entries with IPv6 addresses are not
+// parsed and are kept among the unprocessed lines instead.
+type mailerLine struct {
+	entry       *models.MailerEntry
+	unprocessed bool
+	index       int
+}
+
+func parseMailerLines(mailersSection string, p *parser.Parser) ([]mailerLine, error) {
+	lines := []mailerLine{}
+
+	data, err := p.Get(parser.Mailers, mailersSection, "mailer", false)
+	if err != nil && !errors.Is(err, parser_errors.ErrFetch) {
+		return nil, err
+	}
+	if err == nil {
+		for i, m := range data.([]types.Mailer) {
+			lines = append(lines, mailerLine{entry: ParseMailerEntry(m), index: i})
+		}
+	}
+
+	data, err = p.Get(parser.Mailers, mailersSection, "", false)
+	if err != nil && !errors.Is(err, parser_errors.ErrFetch) {
+		return nil, err
+	}
+	if err == nil {
+		for i, u := range data.([]types.UnProcessed) {
+			parts := strings.Fields(u.Value)
+			if len(parts) < 3 || parts[0] != "mailer" {
+				continue
+			}
+			ip, portStr, ok := splitHostPort(parts[2])
+			if !ok {
+				continue
+			}
+			port, err := strconv.ParseInt(portStr, 10, 64)
+			if err != nil {
+				continue
+			}
+			entry := ParseMailerEntry(types.Mailer{Name: parts[1], IP: ip, Port: port})
+			lines = append(lines, mailerLine{entry: entry, unprocessed: true, index: i})
+		}
+	}
+	return lines, nil
+}
+
+func getMailerLine(name string, mailersSection string, p *parser.Parser) *mailerLine {
+	lines, err := parseMailerLines(mailersSection, p)
+	if err != nil {
+		return nil
+	}
+	for _, l := range lines {
+		if l.entry.Name == name {
+			return &l
+		}
+	}
+	return nil
+}
+
+func insertMailerLine(mailersSection string, data *models.MailerEntry, p *parser.Parser) error {
+	if !isIPv6Address(*data.Address) {
+		return p.Insert(parser.Mailers, mailersSection, "mailer", SerializeMailerEntry(*data), -1)
+	}
+	lines, err := getMailersUnprocessed(mailersSection, p)
+	if err != nil {
+		return err
+	}
+	lines = append(lines, types.UnProcessed{Value: fmt.Sprintf("mailer %s %s:%d", data.Name, *data.Address, *data.Port)})
+	return p.Set(parser.Mailers, mailersSection, "", lines)
+}
+
+func deleteMailerLine(mailersSection string, line *mailerLine, p *parser.Parser) error {
+	if !line.unprocessed {
+		return p.Delete(parser.Mailers, mailersSection, "mailer", line.index)
+	}
+	lines, err := getMailersUnprocessed(mailersSection, p)
+	if err != nil {
+		return err
+	}
+	lines = append(lines[:line.index], lines[line.index+1:]...)
+	return p.Set(parser.Mailers, mailersSection, "", lines)
+}
+
+func getMailersUnprocessed(mailersSection string, p *parser.Parser) ([]types.UnProcessed, error) {
+	data, err := p.Get(parser.Mailers, mailersSection, "", false)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrFetch) {
+			return []types.UnProcessed{}, nil
+		}
+		return nil, err
+	}
+	return data.([]types.UnProcessed), nil
+}
+
+func isIPv6Address(address string) bool {
+	return strings.Contains(address, ":")
+}
+
+func ParseMailerEntries(mailersSection string, p *parser.Parser) (models.MailerEntries, error) {
+	mailerEntries := models.MailerEntries{}
+
+	lines, err := parseMailerLines(mailersSection, p)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lines {
+		mailerEntries = append(mailerEntries, l.entry)
+	}
+	return mailerEntries, nil
+}
+
+func ParseMailerEntry(m types.Mailer) *models.MailerEntry {
+	return &models.MailerEntry{
+		Address: &m.IP,
+		Port:    &m.Port,
+		Name:    m.Name,
+	}
+}
+
+func SerializeMailerEntry(me models.MailerEntry) types.Mailer {
+	return types.Mailer{
+		Name: me.Name,
+		IP:   *me.Address,
+		Port: *me.Port,
+	}
+}
+
+func GetMailerEntryByName(name string, mailersSection string, p *parser.Parser) (*models.MailerEntry, int) {
+	mailerEntries, err := ParseMailerEntries(mailersSection, p)
+	if err != nil {
+		return nil, 0
+	}
+
+	for i, m := range mailerEntries {
+		if m.Name == name {
+			return m, i
+		}
+	}
+	return nil, 0
+}
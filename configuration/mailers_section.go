@@ -0,0 +1,197 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"fmt"
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetMailersSections returns configuration version and an array of
+// configured mailers sections. Returns error on fail.
+func (c *Client) GetMailersSections(transactionID string) (int64, models.MailersSections, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	names, err := p.SectionsGet(parser.Mailers)
+	if err != nil {
+		return v, nil, err
+	}
+
+	sections := models.MailersSections{}
+	for _, name := range names {
+		section := &models.MailersSection{Name: name}
+		if err := ParseMailersSection(p, section); err != nil {
+			return v, nil, err
+		}
+		sections = append(sections, section)
+	}
+
+	return v, sections, nil
+}
+
+// GetMailersSection returns configuration version and a requested mailers section.
+// Returns error on fail or if mailers section does not exist.
+func (c *Client) GetMailersSection(name string, transactionID string) (int64, *models.MailersSection, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Mailers, name, p) {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Mailers section %s does not exist", name))
+	}
+
+	section := &models.MailersSection{Name: name}
+	if err := ParseMailersSection(p, section); err != nil {
+		return v, nil, err
+	}
+
+	return v, section, nil
+}
+
+// DeleteMailersSection deletes a mailers section in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteMailersSection(name string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Mailers, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Mailers, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsDelete(parser.Mailers, name); err != nil {
+		return c.HandleError(name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditMailersSection edits a mailers section in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditMailersSection(name string, data *models.MailersSection, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Mailers, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Mailers, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := SerializeMailersSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateMailersSection creates a mailers section in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateMailersSection(data *models.MailersSection, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if c.checkSectionExists(parser.Mailers, data.Name, p) {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("%s %s already exists", parser.Mailers, data.Name))
+		return c.HandleError(data.Name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsCreate(parser.Mailers, data.Name); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := SerializeMailersSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ParseMailersSection(p *parser.Parser, section *models.MailersSection) error {
+	data, err := p.Get(parser.Mailers, section.Name, "timeout mail", false)
+	if err != nil {
+		return nil
+	}
+	if d, ok := data.(*types.StringC); ok && d != nil {
+		section.Timeout = misc.ParseTimeout(d.Value)
+	}
+	return nil
+}
+
+func SerializeMailersSection(p *parser.Parser, data *models.MailersSection) error {
+	if data.Timeout == nil {
+		return p.Set(parser.Mailers, data.Name, "timeout mail", nil)
+	}
+	return p.Set(parser.Mailers, data.Name, "timeout mail", types.StringC{Value: strconv.FormatInt(*data.Timeout, 10)})
+}
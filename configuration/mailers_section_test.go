@@ -0,0 +1,156 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestCreateEditDeleteMailersSection(t *testing.T) {
+	m := &models.MailersSection{
+		Name:    "alerts",
+		Timeout: misc.Int64P(20000),
+	}
+	err := client.CreateMailersSection(m, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	v, section, err := client.GetMailersSection("alerts", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(m, section) {
+		t.Errorf("Created mailers section %v not equal to given mailers section %v", section, m)
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	err = client.CreateMailersSection(m, "", version)
+	if err == nil {
+		t.Error("Should throw error mailers section already exists")
+		version++
+	}
+
+	ipv4 := "10.0.0.25"
+	ipv6 := "2001:db8::25"
+	entries := models.MailerEntries{
+		&models.MailerEntry{Name: "smtp1", Address: &ipv4, Port: misc.Int64P(25)},
+		&models.MailerEntry{Name: "smtp2", Address: &ipv6, Port: misc.Int64P(587)},
+	}
+	for _, e := range entries {
+		if err = client.CreateMailerEntry("alerts", e, "", version); err != nil {
+			t.Fatal(err.Error())
+		}
+		version++
+	}
+
+	_, mailerEntries, err := client.GetMailerEntries("alerts", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(mailerEntries, entries) {
+		t.Errorf("Mailer entries %v returned, expected %v", mailerEntries, entries)
+	}
+
+	_, raw, err := client.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !strings.Contains(raw, "mailer smtp2 2001:db8::25:587") {
+		t.Errorf("IPv6 mailer entry not found in configuration:\n%s", raw)
+	}
+
+	err = client.CreateMailerEntry("alerts", entries[0], "", version)
+	if err == nil {
+		t.Error("Should throw error mailer entry already exists")
+		version++
+	}
+
+	err = client.CreateMailerEntry("doesnotexist", entries[0], "", version)
+	if err == nil {
+		t.Error("Should throw error, mailers section does not exist")
+		version++
+	}
+
+	e := &models.MailerEntry{Name: "smtp2", Address: &ipv4, Port: misc.Int64P(2525)}
+	err = client.EditMailerEntry("smtp2", "alerts", e, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, entry, err := client.GetMailerEntry("smtp2", "alerts", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(e, entry) {
+		t.Errorf("Edited mailer entry %v not equal to given mailer entry %v", entry, e)
+	}
+
+	m = &models.MailersSection{Name: "alerts"}
+	err = client.EditMailersSection("alerts", m, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, section, err = client.GetMailersSection("alerts", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(m, section) {
+		t.Errorf("Edited mailers section %v not equal to given mailers section %v", section, m)
+	}
+
+	for _, name := range []string{"smtp1", "smtp2"} {
+		if err = client.DeleteMailerEntry(name, "alerts", "", version); err != nil {
+			t.Error(err.Error())
+		} else {
+			version++
+		}
+	}
+
+	_, _, err = client.GetMailerEntry("smtp1", "alerts", "")
+	if err == nil {
+		t.Error("DeleteMailerEntry failed, mailer entry smtp1 still exists")
+	}
+
+	err = client.DeleteMailersSection("alerts", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetMailersSection("alerts", "")
+	if err == nil {
+		t.Error("DeleteMailersSection failed, mailers section alerts still exists")
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 
 	strfmt "github.com/go-openapi/strfmt"
 	parser "github.com/haproxytech/config-parser/v3"
@@ -63,6 +62,10 @@ func (c *Client) GetNameserver(name string, resolverSection string, transactionI
 		return 0, nil, err
 	}
 
+	if !c.checkSectionExists(parser.Resolvers, resolverSection, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Resolvers section %s does not exist", resolverSection))
+	}
+
 	nameserver, _ := GetNameserverByName(name, resolverSection, p)
 	if nameserver == nil {
 		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Nameserver %s does not exist in resolvers section %s", name, resolverSection))
@@ -79,6 +82,11 @@ func (c *Client) DeleteNameserver(name string, resolverSection string, transacti
 		return err
 	}
 
+	if !c.checkSectionExists(parser.Resolvers, resolverSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Resolvers section %s does not exist", resolverSection))
+		return c.HandleError(name, "resolvers", resolverSection, t, transactionID == "", e)
+	}
+
 	nameserver, i := GetNameserverByName(name, resolverSection, p)
 	if nameserver == nil {
 		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Nameserver %s does not exist in resolvers section %s", name, resolverSection))
@@ -101,7 +109,10 @@ func (c *Client) CreateNameserver(resolverSection string, data *models.Nameserve
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -109,6 +120,11 @@ func (c *Client) CreateNameserver(resolverSection string, data *models.Nameserve
 		return err
 	}
 
+	if !c.checkSectionExists(parser.Resolvers, resolverSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Resolvers section %s does not exist", resolverSection))
+		return c.HandleError(data.Name, "resolvers", resolverSection, t, transactionID == "", e)
+	}
+
 	nameserver, _ := GetNameserverByName(data.Name, resolverSection, p)
 	if nameserver != nil {
 		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Nameserver %s already exists in resolvers section %s", data.Name, resolverSection))
@@ -132,7 +148,10 @@ func (c *Client) EditNameserver(name string, resolverSection string, data *model
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -140,6 +159,11 @@ func (c *Client) EditNameserver(name string, resolverSection string, data *model
 		return err
 	}
 
+	if !c.checkSectionExists(parser.Resolvers, resolverSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Resolvers section %s does not exist", resolverSection))
+		return c.HandleError(data.Name, "resolvers", resolverSection, t, transactionID == "", e)
+	}
+
 	nameserver, i := GetNameserverByName(name, resolverSection, p)
 	if nameserver == nil {
 		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Nameserver %v does not exist in resolvers section %s", name, resolverSection))
@@ -179,12 +203,11 @@ func ParseNameservers(resolverSection string, p *parser.Parser) (models.Nameserv
 }
 
 func ParseNameserver(p types.Nameserver) *models.Nameserver {
-	parts := strings.Split(p.Address, ":")
-	if len(parts) != 2 {
+	ip, portStr, ok := splitHostPort(p.Address)
+	if !ok {
 		return nil
 	}
-	ip := parts[0]
-	port, err := strconv.ParseInt(parts[1], 10, 64)
+	port, err := strconv.ParseInt(portStr, 10, 64)
 	if err != nil {
 		return nil
 	}
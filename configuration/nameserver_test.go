@@ -175,3 +175,53 @@ func TestCreateEditDeleteNameserver(t *testing.T) {
 		version++
 	}
 }
+
+func TestCreateIPv6Nameserver(t *testing.T) {
+	address := "2001:db8::53"
+	port := int64(53)
+	e := &models.Nameserver{
+		Name:    "dns6",
+		Address: &address,
+		Port:    &port,
+	}
+	err := client.CreateNameserver("test", e, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, nameserver, err := client.GetNameserver("dns6", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(nameserver, e) {
+		t.Errorf("Created nameserver %v:%v not equal to given nameserver %v:%v", *nameserver.Address, *nameserver.Port, address, port)
+	}
+
+	err = client.DeleteNameserver("dns6", "test", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestNameserverMissingResolvers(t *testing.T) {
+	address := "10.0.0.2"
+	port := int64(53)
+	e := &models.Nameserver{
+		Name:    "dns2",
+		Address: &address,
+		Port:    &port,
+	}
+	errs := map[string]error{}
+	_, _, errs["get"] = client.GetNameserver("dns1", "doesnotexist", "")
+	errs["create"] = client.CreateNameserver("doesnotexist", e, "", version)
+	errs["edit"] = client.EditNameserver("dns1", "doesnotexist", e, "", version)
+	errs["delete"] = client.DeleteNameserver("dns1", "doesnotexist", "", version)
+	for op, err := range errs {
+		if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+			t.Errorf("%s: expected ErrParentDoesNotExist, got %v", op, err)
+		}
+	}
+}
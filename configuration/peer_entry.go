@@ -61,6 +61,10 @@ func (c *Client) GetPeerEntry(name string, peerSection string, transactionID str
 		return 0, nil, err
 	}
 
+	if !c.checkSectionExists(parser.Peers, peerSection, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Peer section %s does not exist", peerSection))
+	}
+
 	peerEntry, _ := GetPeerEntryByName(name, peerSection, p)
 	if peerEntry == nil {
 		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("PeerEntry %s does not exist in peer section %s", name, peerSection))
@@ -77,6 +81,11 @@ func (c *Client) DeletePeerEntry(name string, peerSection string, transactionID
 		return err
 	}
 
+	if !c.checkSectionExists(parser.Peers, peerSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Peer section %s does not exist", peerSection))
+		return c.HandleError(name, "peers", peerSection, t, transactionID == "", e)
+	}
+
 	peerEntry, i := GetPeerEntryByName(name, peerSection, p)
 	if peerEntry == nil {
 		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("PeerEntry %s does not exist in peer section %s", name, peerSection))
@@ -99,7 +108,10 @@ func (c *Client) CreatePeerEntry(peerSection string, data *models.PeerEntry, tra
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -107,6 +119,11 @@ func (c *Client) CreatePeerEntry(peerSection string, data *models.PeerEntry, tra
 		return err
 	}
 
+	if !c.checkSectionExists(parser.Peers, peerSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Peer section %s does not exist", peerSection))
+		return c.HandleError(data.Name, "peers", peerSection, t, transactionID == "", e)
+	}
+
 	peerEntry, _ := GetPeerEntryByName(data.Name, peerSection, p)
 	if peerEntry != nil {
 		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("PeerEntry %s already exists in peer section %s", data.Name, peerSection))
@@ -130,7 +147,10 @@ func (c *Client) EditPeerEntry(name string, peerSection string, data *models.Pee
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -138,6 +158,11 @@ func (c *Client) EditPeerEntry(name string, peerSection string, data *models.Pee
 		return err
 	}
 
+	if !c.checkSectionExists(parser.Peers, peerSection, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Peer section %s does not exist", peerSection))
+		return c.HandleError(data.Name, "peers", peerSection, t, transactionID == "", e)
+	}
+
 	peerEntry, i := GetPeerEntryByName(name, peerSection, p)
 	if peerEntry == nil {
 		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("PeerEntry %v does not exist in peer section %s", name, peerSection))
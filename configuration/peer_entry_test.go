@@ -180,3 +180,57 @@ func TestCreateEditDeletePeerEntry(t *testing.T) {
 		version++
 	}
 }
+
+func TestCreateTwoDeleteOnePeerEntry(t *testing.T) {
+	err := client.CreatePeerSection(&models.PeerSection{Name: "sync"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	for i, name := range []string{"haproxy1", "haproxy2"} {
+		address := fmt.Sprintf("10.0.0.%d", i+1)
+		port := int64(10000)
+		e := &models.PeerEntry{Name: name, Address: &address, Port: &port}
+		if err := client.CreatePeerEntry("sync", e, "", version); err != nil {
+			t.Fatal(err.Error())
+		}
+		version++
+	}
+
+	_, peerEntries, err := client.GetPeerEntries("sync", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(peerEntries) != 2 {
+		t.Errorf("%v peer entries returned, expected 2", len(peerEntries))
+	}
+
+	if err := client.DeletePeerEntry("haproxy1", "sync", "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, peerEntries, err = client.GetPeerEntries("sync", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(peerEntries) != 1 || peerEntries[0].Name != "haproxy2" || *peerEntries[0].Address != "10.0.0.2" {
+		t.Errorf("Expected only haproxy2 10.0.0.2 left, %v found", peerEntries)
+	}
+
+	_, _, err = client.GetPeerEntry("haproxy2", "doesnotexist", "")
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected ErrParentDoesNotExist, got %v", err)
+	}
+	err = client.DeletePeerEntry("haproxy2", "doesnotexist", "", version)
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected ErrParentDoesNotExist, got %v", err)
+	}
+
+	if err := client.DeletePeerSection("sync", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
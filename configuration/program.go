@@ -0,0 +1,234 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"fmt"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/common"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetPrograms returns configuration version and an array of
+// configured programs. Returns error on fail.
+func (c *Client) GetPrograms(transactionID string) (int64, models.Programs, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	names, err := p.SectionsGet(parser.Program)
+	if err != nil {
+		return v, nil, err
+	}
+
+	programs := models.Programs{}
+	for _, name := range names {
+		program := &models.Program{Name: name}
+		if err := ParseProgramSection(p, program); err != nil {
+			return v, nil, err
+		}
+		programs = append(programs, program)
+	}
+
+	return v, programs, nil
+}
+
+// GetProgram returns configuration version and a requested program.
+// Returns error on fail or if program does not exist.
+func (c *Client) GetProgram(name string, transactionID string) (int64, *models.Program, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Program, name, p) {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Program %s does not exist", name))
+	}
+
+	program := &models.Program{Name: name}
+	if err := ParseProgramSection(p, program); err != nil {
+		return v, nil, err
+	}
+
+	return v, program, nil
+}
+
+// DeleteProgram deletes a program in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteProgram(name string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Program, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Program, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsDelete(parser.Program, name); err != nil {
+		return c.HandleError(name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditProgram edits a program in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditProgram(name string, data *models.Program, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Program, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Program, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := SerializeProgramSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateProgram creates a program in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateProgram(data *models.Program, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if c.checkSectionExists(parser.Program, data.Name, p) {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("%s %s already exists", parser.Program, data.Name))
+		return c.HandleError(data.Name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsCreate(parser.Program, data.Name); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := SerializeProgramSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ParseProgramSection(p *parser.Parser, program *models.Program) error {
+	for attribute, field := range programStrings(program) {
+		data, err := p.Get(parser.Program, program.Name, attribute, false)
+		if err != nil {
+			continue
+		}
+		if d, ok := data.(*types.StringC); ok && d != nil {
+			*field = d.Value
+		}
+	}
+
+	data, err := p.Get(parser.Program, program.Name, "option start-on-reload", false)
+	if err == nil {
+		if d, ok := data.(*types.SimpleOption); ok && d != nil {
+			if d.NoOption {
+				program.StartOnReload = "disabled"
+			} else {
+				program.StartOnReload = "enabled"
+			}
+		}
+	}
+	return nil
+}
+
+func SerializeProgramSection(p *parser.Parser, data *models.Program) error {
+	for attribute, field := range programStrings(data) {
+		if *field == "" {
+			if err := p.Set(parser.Program, data.Name, attribute, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.Set(parser.Program, data.Name, attribute, types.StringC{Value: *field}); err != nil {
+			return err
+		}
+	}
+
+	var option common.ParserData
+	switch data.StartOnReload {
+	case "enabled":
+		option = types.SimpleOption{}
+	case "disabled":
+		option = types.SimpleOption{NoOption: true}
+	}
+	return p.Set(parser.Program, data.Name, "option start-on-reload", option)
+}
+
+// programStrings maps the program section keywords to the model fields
+func programStrings(program *models.Program) map[string]*string {
+	return map[string]*string{
+		"command": &program.Command,
+		"user":    &program.User,
+		"group":   &program.Group,
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestCreateEditDeleteProgram(t *testing.T) {
+	p := &models.Program{
+		Name:          "sidecar",
+		Command:       "/usr/bin/sidecar --port 8081",
+		User:          "haproxy",
+		Group:         "haproxy",
+		StartOnReload: "enabled",
+	}
+	err := client.CreateProgram(p, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	v, program, err := client.GetProgram("sidecar", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(p, program) {
+		t.Errorf("Created program %v not equal to given program %v", program, p)
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	_, programs, err := client.GetPrograms("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(programs) != 1 {
+		t.Errorf("%v programs returned, expected 1", len(programs))
+	}
+
+	err = client.CreateProgram(p, "", version)
+	if err == nil {
+		t.Error("Should throw error program already exists")
+		version++
+	}
+
+	err = client.CreateProgram(&models.Program{Name: "nocommand"}, "", version)
+	if err == nil {
+		t.Error("Should throw error, program without command")
+		version++
+	}
+
+	p = &models.Program{
+		Name:          "sidecar",
+		Command:       "/usr/bin/sidecar --port 8082",
+		StartOnReload: "disabled",
+	}
+	err = client.EditProgram("sidecar", p, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, program, err = client.GetProgram("sidecar", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(p, program) {
+		t.Errorf("Edited program %v not equal to given program %v", program, p)
+	}
+
+	err = client.DeleteProgram("sidecar", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetProgram("sidecar", "")
+	if err == nil {
+		t.Error("DeleteProgram failed, program sidecar still exists")
+	}
+
+	err = client.DeleteProgram("sidecar", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existant program")
+		version++
+	}
+}
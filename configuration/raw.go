@@ -76,6 +76,31 @@ func (c *Client) GetRawConfiguration(transactionID string, version int64) (int64
 	return ondiskV, dataStr, nil
 }
 
+// CanonicalizeConfiguration rewrites the configuration in its canonical form,
+// so that configurations generated from models and hand-written ones diff
+// cleanly. One of version or transactionID is mandatory. Returns error on fail,
+// nil on success.
+//
+// The canonical form is the one written by every change made with the client:
+// global and defaults come first, followed by the userlist, peers, mailers,
+// resolvers, cache, ring, http-errors, frontend, backend and program sections,
+// each kind sorted by name. Within a section keywords follow the config-parser
+// order, lines of the same keyword (binds, servers, rules) keep their relative
+// order, and every line is indented with two spaces. Canonicalizing an already
+// canonical configuration does not change it.
+func (c *Client) CanonicalizeConfiguration(transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // PostRawConfiguration pushes given string to the config file if the version
 // matches
 func (c *Client) PostRawConfiguration(config *string, version int64, skipVersionCheck bool, onlyValidate ...bool) error {
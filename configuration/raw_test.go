@@ -0,0 +1,77 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestCanonicalizeConfiguration(t *testing.T) {
+	input, err := ioutil.ReadFile("testdata/canonical.cfg")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	golden, err := ioutil.ReadFile("testdata/canonical.golden.cfg")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	path := "/tmp/haproxy-canonical.cfg"
+	if err := prepareTestFile(string(input), path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.CanonicalizeConfiguration("", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+	_, canonical, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if canonical != string(golden) {
+		t.Errorf("Canonical configuration differs from testdata/canonical.golden.cfg:\n%s", canonical)
+	}
+
+	// canonicalizing again within a transaction is a no-op
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.CanonicalizeConfiguration(tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := c.CommitTransaction(tr.ID); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, again, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if again != canonical {
+		t.Errorf("Canonicalizing twice changed the configuration:\n%s", again)
+	}
+}
@@ -112,7 +112,10 @@ func (c *Client) EditResolver(name string, data *models.Resolver, transactionID
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -127,7 +130,7 @@ func (c *Client) EditResolver(name string, data *models.Resolver, transactionID
 	}
 
 	if err = SerializeResolverSection(p, data); err != nil {
-		return err
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -143,7 +146,10 @@ func (c *Client) CreateResolver(data *models.Resolver, transactionID string, ver
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 
@@ -162,7 +168,7 @@ func (c *Client) CreateResolver(data *models.Resolver, transactionID string, ver
 	}
 
 	if err = SerializeResolverSection(p, data); err != nil {
-		return err
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -254,7 +260,8 @@ func ParseResolverSection(p *parser.Parser, resolver *models.Resolver) error { /
 		}
 	}
 
-	return err
+	// directives missing from the section are left empty
+	return nil
 }
 
 func SerializeResolverSection(p *parser.Parser, data *models.Resolver) error { //nolint:gocognit,gocyclo
@@ -143,3 +143,52 @@ func TestCreateEditDeleteResolver(t *testing.T) {
 		version++
 	}
 }
+
+func TestCreateMinimalResolver(t *testing.T) {
+	err := client.CreateResolver(&models.Resolver{Name: "minimal_resolver"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	err = client.CreateNameserver("minimal_resolver", &models.Nameserver{
+		Name:    "dns1",
+		Address: misc.StringP("10.0.0.1"),
+		Port:    misc.Int64P(53),
+	}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, resolver, err := client.GetResolver("minimal_resolver", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(resolver, &models.Resolver{Name: "minimal_resolver"}) {
+		t.Errorf("Expected empty minimal_resolver, %v found", resolver)
+	}
+
+	_, resolvers, err := client.GetResolvers("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(resolvers) != 2 {
+		t.Errorf("%v resolvers returned, expected 2", len(resolvers))
+	}
+
+	_, nameservers, err := client.GetNameservers("minimal_resolver", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(nameservers) != 1 || nameservers[0].Name != "dns1" || *nameservers[0].Address != "10.0.0.1" || *nameservers[0].Port != 53 {
+		t.Errorf("Expected nameserver dns1 10.0.0.1:53, %v found", nameservers)
+	}
+
+	err = client.DeleteResolver("minimal_resolver", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	parser_errors "github.com/haproxytech/config-parser/v3/errors"
+	"github.com/haproxytech/config-parser/v3/params"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetRings returns configuration version and an array of
+// configured rings. Returns error on fail.
+func (c *Client) GetRings(transactionID string) (int64, models.Rings, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	names, err := p.SectionsGet(parser.Ring)
+	if err != nil {
+		return v, nil, err
+	}
+
+	rings := models.Rings{}
+	for _, name := range names {
+		ring, err := ParseRingSection(p, name)
+		if err != nil {
+			return v, nil, err
+		}
+		rings = append(rings, ring)
+	}
+
+	return v, rings, nil
+}
+
+// GetRing returns configuration version and a requested ring.
+// Returns error on fail or if ring does not exist.
+func (c *Client) GetRing(name string, transactionID string) (int64, *models.Ring, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Ring, name, p) {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Ring %s does not exist", name))
+	}
+
+	ring, err := ParseRingSection(p, name)
+	if err != nil {
+		return v, nil, err
+	}
+
+	return v, ring, nil
+}
+
+// DeleteRing deletes a ring in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteRing(name string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Ring, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Ring, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsDelete(parser.Ring, name); err != nil {
+		return c.HandleError(name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditRing edits a ring in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditRing(name string, data *models.Ring, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Ring, name, p) {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("%s %s does not exist", parser.Ring, name))
+		return c.HandleError(name, "", "", t, transactionID == "", e)
+	}
+
+	if err := SerializeRingSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateRing creates a ring in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateRing(data *models.Ring, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if c.checkSectionExists(parser.Ring, data.Name, p) {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("%s %s already exists", parser.Ring, data.Name))
+		return c.HandleError(data.Name, "", "", t, transactionID == "", e)
+	}
+
+	if err := p.SectionsCreate(parser.Ring, data.Name); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := SerializeRingSection(p, data); err != nil {
+		return c.HandleError(data.Name, "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseRingSection reads a ring section. config-parser has no parsers for
+// this section, so its lines are read from the unprocessed ones.
+func ParseRingSection(p *parser.Parser, name string) (*models.Ring, error) {
+	ring := &models.Ring{Name: name, Servers: models.Servers{}}
+
+	data, err := p.Get(parser.Ring, name, "", false)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrFetch) {
+			return ring, nil
+		}
+		return nil, err
+	}
+
+	for _, line := range data.([]types.UnProcessed) {
+		parts := strings.Fields(line.Value)
+		if len(parts) < 2 {
+			continue
+		}
+		switch parts[0] {
+		case "description":
+			ring.Description = strings.Join(parts[1:], " ")
+		case "format":
+			ring.Format = parts[1]
+		case "maxlen":
+			if v, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+				ring.Maxlen = &v
+			}
+		case "size":
+			ring.Size = misc.ParseSize(parts[1])
+		case "timeout":
+			if len(parts) < 3 {
+				continue
+			}
+			switch parts[1] {
+			case "connect":
+				ring.TimeoutConnect = misc.ParseTimeout(parts[2])
+			case "server":
+				ring.TimeoutServer = misc.ParseTimeout(parts[2])
+			}
+		case "server":
+			if len(parts) < 3 {
+				continue
+			}
+			s := ParseServer(types.Server{
+				Name:    parts[1],
+				Address: parts[2],
+				Params:  params.ParseServerOptions(parts[3:]),
+			})
+			if s != nil {
+				ring.Servers = append(ring.Servers, s)
+			}
+		}
+	}
+	return ring, nil
+}
+
+// SerializeRingSection replaces the lines of an existing ring section that
+// are managed by the Ring model, other lines of the section are kept.
+func SerializeRingSection(p *parser.Parser, data *models.Ring) error {
+	lines := []types.UnProcessed{}
+	current, err := p.Get(parser.Ring, data.Name, "", false)
+	if err != nil && !errors.Is(err, parser_errors.ErrFetch) {
+		return err
+	}
+	if err == nil {
+		for _, line := range current.([]types.UnProcessed) {
+			if !isRingKeyword(line.Value) {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	add := func(format string, a ...interface{}) {
+		lines = append(lines, types.UnProcessed{Value: fmt.Sprintf(format, a...)})
+	}
+	if data.Description != "" {
+		add("description %s", data.Description)
+	}
+	if data.Format != "" {
+		add("format %s", data.Format)
+	}
+	if data.Maxlen != nil {
+		add("maxlen %d", *data.Maxlen)
+	}
+	if data.Size != nil {
+		add("size %d", *data.Size)
+	}
+	if data.TimeoutConnect != nil {
+		add("timeout connect %d", *data.TimeoutConnect)
+	}
+	if data.TimeoutServer != nil {
+		add("timeout server %d", *data.TimeoutServer)
+	}
+	for _, s := range data.Servers {
+		if s == nil {
+			continue
+		}
+		srv := SerializeServer(*s)
+		line := fmt.Sprintf("server %s %s", srv.Name, srv.Address)
+		if opts := params.ServerOptionsString(srv.Params); opts != "" {
+			line += " " + opts
+		}
+		lines = append(lines, types.UnProcessed{Value: line})
+	}
+	return p.Set(parser.Ring, data.Name, "", lines)
+}
+
+// isRingKeyword reports whether a ring section line is managed by the Ring model
+func isRingKeyword(line string) bool {
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return false
+	}
+	switch parts[0] {
+	case "description", "format", "maxlen", "size", "server":
+		return true
+	case "timeout":
+		return len(parts) > 1 && (parts[1] == "connect" || parts[1] == "server")
+	}
+	return false
+}
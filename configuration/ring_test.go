@@ -0,0 +1,120 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestCreateEditDeleteRing(t *testing.T) {
+	r := &models.Ring{
+		Name:           "logbuffer",
+		Description:    "buffer for syslog",
+		Format:         "rfc3164",
+		Maxlen:         misc.Int64P(1200),
+		Size:           misc.Int64P(32764),
+		TimeoutConnect: misc.Int64P(5000),
+		TimeoutServer:  misc.Int64P(10000),
+		Servers: models.Servers{
+			&models.Server{Name: "syslog", Address: "10.0.0.1", Port: misc.Int64P(514), LogProto: "octet-count"},
+		},
+	}
+	err := client.CreateRing(r, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	v, ring, err := client.GetRing("logbuffer", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(r, ring) {
+		t.Errorf("Created ring %v not equal to given ring %v", ring, r)
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	_, rings, err := client.GetRings("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(rings) != 1 {
+		t.Errorf("%v rings returned, expected 1", len(rings))
+	}
+
+	err = client.CreateRing(r, "", version)
+	if err == nil {
+		t.Error("Should throw error ring already exists")
+		version++
+	}
+
+	err = client.CreateRing(&models.Ring{Name: "badformat", Format: "json"}, "", version)
+	if err == nil {
+		t.Error("Should throw error, unknown ring format")
+		version++
+	}
+
+	r = &models.Ring{
+		Name:   "logbuffer",
+		Format: "rfc5424",
+		Size:   misc.Int64P(65536),
+		Servers: models.Servers{
+			&models.Server{Name: "syslog", Address: "10.0.0.2", Port: misc.Int64P(6514)},
+		},
+	}
+	err = client.EditRing("logbuffer", r, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	_, ring, err = client.GetRing("logbuffer", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(r, ring) {
+		t.Errorf("Edited ring %v not equal to given ring %v", ring, r)
+	}
+
+	err = client.DeleteRing("logbuffer", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetRing("logbuffer", "")
+	if err == nil {
+		t.Error("DeleteRing failed, ring logbuffer still exists")
+	}
+
+	err = client.DeleteRing("logbuffer", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existant ring")
+		version++
+	}
+}
@@ -16,8 +16,11 @@
 package configuration
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -52,6 +55,61 @@ func (c *Client) GetServers(backend string, transactionID string) (int64, models
 	return v, servers, nil
 }
 
+// WalkServers calls fn with each server of every backend, backends sorted by
+// name and servers in configuration order. Servers are parsed one at a time, so
+// the memory used does not grow with the number of servers. The walk stops at
+// the first error returned by fn, or with ctx.Err() as soon as ctx is done, and
+// that error is returned. Returns configuration version and error on fail, nil
+// on success.
+func (c *Client) WalkServers(ctx context.Context, transactionID string, fn func(backend string, server *models.Server) error) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, err
+	}
+
+	backends, err := p.SectionsGet(parser.Backends)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrSectionMissing) {
+			return v, nil
+		}
+		return v, err
+	}
+	sort.Strings(backends)
+
+	for _, backend := range backends {
+		data, err := p.Get(parser.Backends, backend, "server", false)
+		if err != nil {
+			if errors.Is(err, parser_errors.ErrFetch) {
+				continue
+			}
+			return v, c.HandleError("", "backend", backend, "", false, err)
+		}
+		for _, ondiskServer := range data.([]types.Server) {
+			if err := ctx.Err(); err != nil {
+				return v, err
+			}
+			s := ParseServer(ondiskServer)
+			if s == nil {
+				continue
+			}
+			if err := fn(backend, s); err != nil {
+				return v, err
+			}
+		}
+	}
+
+	return v, nil
+}
+
 // GetServer returns configuration version and a requested server
 // in the specified backend. Returns error on fail or if server does not exist.
 func (c *Client) GetServer(name string, backend string, transactionID string) (int64, *models.Server, error) {
@@ -104,7 +162,15 @@ func (c *Client) CreateServer(backend string, data *models.Server, transactionID
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		if !c.SkipSemanticValidation {
+			if err := validateServer(data); err != nil {
+				return err
+			}
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -118,6 +184,12 @@ func (c *Client) CreateServer(backend string, data *models.Server, transactionID
 		return c.HandleError(data.Name, "backend", backend, t, transactionID == "", e)
 	}
 
+	if c.UseValidation && !c.SkipSemanticValidation {
+		if err := c.validateServerResolvers(data, p); err != nil {
+			return c.HandleError(data.Name, "backend", backend, t, transactionID == "", err)
+		}
+	}
+
 	if err := p.Insert(parser.Backends, backend, "server", SerializeServer(*data), -1); err != nil {
 		return c.HandleError(data.Name, "backend", backend, t, transactionID == "", err)
 	}
@@ -134,7 +206,15 @@ func (c *Client) EditServer(name string, backend string, data *models.Server, tr
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		if !c.SkipSemanticValidation {
+			if err := validateServer(data); err != nil {
+				return err
+			}
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -148,6 +228,12 @@ func (c *Client) EditServer(name string, backend string, data *models.Server, tr
 		return c.HandleError(data.Name, "backend", backend, t, transactionID == "", e)
 	}
 
+	if c.UseValidation && !c.SkipSemanticValidation {
+		if err := c.validateServerResolvers(data, p); err != nil {
+			return c.HandleError(data.Name, "backend", backend, t, transactionID == "", err)
+		}
+	}
+
 	if err := p.Set(parser.Backends, backend, "server", SerializeServer(*data), i); err != nil {
 		return c.HandleError(data.Name, "backend", backend, t, transactionID == "", err)
 	}
@@ -158,6 +244,116 @@ func (c *Client) EditServer(name string, backend string, data *models.Server, tr
 	return nil
 }
 
+// GetServersByBackend returns configuration version, the servers in the
+// specified backend and their indexes in it by name, parsing the backend once.
+// Returns error on fail or if backend does not exist.
+func (c *Client) GetServersByBackend(backend string, transactionID string) (int64, models.Servers, map[string]int, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	if !c.checkSectionExists(parser.Backends, backend, p) {
+		return v, nil, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Backend %s does not exist", backend))
+	}
+
+	servers, err := ParseServers(backend, p)
+	if err != nil {
+		return v, nil, nil, c.HandleError("", "backend", backend, "", false, err)
+	}
+
+	indexes := make(map[string]int, len(servers))
+	for i, s := range servers {
+		indexes[s.Name] = i
+	}
+
+	return v, servers, indexes, nil
+}
+
+// EditServers edits multiple servers of a backend using a single transaction save,
+// data is keyed by the current server names. One of version or transactionID is
+// mandatory. All servers are checked before any is changed, if one of them fails
+// none is edited. Returns error on fail, nil on success.
+func (c *Client) EditServers(backend string, data map[string]*models.Server, transactionID string, version int64) error {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if c.UseValidation {
+		if res := c.validateServerList(names, data); len(res) > 0 {
+			return CompositeTransactionError(res...)
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.Backends, backend, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Backend %s does not exist", backend))
+		return c.HandleError("", "backend", backend, t, transactionID == "", e)
+	}
+
+	ondisk := []types.Server{}
+	if d, err := p.Get(parser.Backends, backend, "server", false); err == nil {
+		ondisk = d.([]types.Server)
+	}
+	indexes := make(map[string]int, len(ondisk))
+	for i, s := range ondisk {
+		indexes[s.Name] = i
+	}
+
+	res := []error{}
+	servers := append([]types.Server{}, ondisk...)
+	for _, name := range names {
+		server := data[name]
+		i, ok := indexes[name]
+		if !ok {
+			res = append(res, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Server %s does not exist in backend %s", name, backend)))
+			continue
+		}
+		if server == nil {
+			res = append(res, NewConfError(ErrGeneralError, fmt.Sprintf("Server %s: no data given", name)))
+			continue
+		}
+		if c.UseValidation && !c.SkipSemanticValidation {
+			if err := c.validateServerResolvers(server, p); err != nil {
+				res = append(res, err)
+				continue
+			}
+		}
+		servers[i] = SerializeServer(*server)
+	}
+	// renamed servers must not clash with each other or with the servers kept
+	seen := make(map[string]struct{}, len(servers))
+	for _, s := range servers {
+		if _, ok := seen[s.Name]; ok {
+			res = append(res, NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("Server %s already exists in backend %s", s.Name, backend)))
+		}
+		seen[s.Name] = struct{}{}
+	}
+	if len(res) > 0 {
+		return c.HandleError("", "backend", backend, t, transactionID == "", CompositeTransactionError(res...))
+	}
+
+	if err := p.Set(parser.Backends, backend, "server", servers); err != nil {
+		return c.HandleError("", "backend", backend, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+	return nil
+}
+
 func ParseServers(backend string, p *parser.Parser) (models.Servers, error) {
 	servers := models.Servers{}
 
@@ -181,7 +377,8 @@ func ParseServers(backend string, p *parser.Parser) (models.Servers, error) {
 
 func ParseServer(ondiskServer types.Server) *models.Server { //nolint:gocognit,gocyclo
 	s := &models.Server{
-		Name: ondiskServer.Name,
+		Name:    ondiskServer.Name,
+		Comment: ondiskServer.Comment,
 	}
 	addSlice := strings.Split(ondiskServer.Address, ":")
 	switch len(addSlice) {
@@ -198,6 +395,7 @@ func ParseServer(ondiskServer types.Server) *models.Server { //nolint:gocognit,g
 			}
 		}
 	}
+	usesrc := ""
 	for _, p := range ondiskServer.Params {
 		switch v := p.(type) {
 		case *params.ServerOptionWord:
@@ -299,6 +497,11 @@ func ParseServer(ondiskServer types.Server) *models.Server { //nolint:gocognit,g
 				if err == nil {
 					s.HealthCheckPort = &p
 				}
+			case "id":
+				id, err := strconv.ParseInt(v.Value, 10, 64)
+				if err == nil {
+					s.ID = &id
+				}
 			case "check-proto":
 				s.CheckProto = v.Value
 			case "cookie":
@@ -403,10 +606,7 @@ func ParseServer(ondiskServer types.Server) *models.Server { //nolint:gocognit,g
 					s.PoolMaxConn = &m
 				}
 			case "pool-purge-delay":
-				d, err := strconv.ParseInt(v.Value, 10, 64)
-				if err == nil && d != 0 {
-					s.PoolPurgeDelay = &d
-				}
+				s.PoolPurgeDelay = misc.ParseTimeout(v.Value)
 			case "redir":
 				s.Redir = v.Value
 			case "rise":
@@ -418,6 +618,8 @@ func ParseServer(ondiskServer types.Server) *models.Server { //nolint:gocognit,g
 				s.ResolveOpts = v.Value
 			case "source":
 				s.Source = v.Value
+			case "usesrc":
+				usesrc = v.Value
 			case "ssl-max-ver":
 				s.SslMaxVer = v.Value
 			case "ssl-min-ver":
@@ -436,13 +638,122 @@ func ParseServer(ondiskServer types.Server) *models.Server { //nolint:gocognit,g
 			}
 		}
 	}
+	// config-parser reads usesrc as an option of its own, it belongs to source
+	if s.Source != "" && usesrc != "" {
+		s.Source = fmt.Sprintf("%s usesrc %s", s.Source, usesrc)
+	}
 	return s
 }
 
+// validateServerResolvers checks that the resolvers section used by the server
+// exists, HAProxy does not start otherwise.
+func (c *Client) validateServerResolvers(data *models.Server, p *parser.Parser) error {
+	if data.Resolvers != "" && !c.checkSectionExists(parser.Resolvers, data.Resolvers, p) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Server %s: resolvers section %s does not exist", data.Name, data.Resolvers))
+	}
+	return nil
+}
+
+// validateServerList validates the servers in names order and returns all errors found
+func (c *Client) validateServerList(names []string, data map[string]*models.Server) []error {
+	res := []error{}
+	for _, name := range names {
+		s := data[name]
+		if s == nil {
+			// reported when the servers are edited
+			continue
+		}
+		if validationErr := s.Validate(strfmt.Default); validationErr != nil {
+			confErr := NewValidationError(validationErr)
+			confErr.msg = fmt.Sprintf("Server %s: %s", s.Name, confErr.msg)
+			res = append(res, confErr)
+			continue
+		}
+		if err := c.runValidators(s); err != nil {
+			res = append(res, err)
+			continue
+		}
+		if c.SkipSemanticValidation {
+			continue
+		}
+		if err := validateServer(s); err != nil {
+			res = append(res, err)
+		}
+	}
+	return res
+}
+
+// ValidateServer checks a server against the model schema and the semantic
+// rules applied when it is created or edited, without loading any configuration.
+// References to other sections, such as resolvers, are not checked. Returns a
+// ConfError with the ErrValidationError code on fail, nil on success.
+func ValidateServer(data *models.Server) error {
+	if err := data.Validate(strfmt.Default); err != nil {
+		return NewValidationError(err)
+	}
+	return validateServer(data)
+}
+
+// validateServer checks option combinations of a server that can not be
+// expressed in the model schema.
+func validateServer(data *models.Server) error {
+	// a server sends one PROXY protocol header, HAProxy uses the last one set
+	if data.SendProxy == "enabled" && data.SendProxyV2 == "enabled" {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Server %s: send-proxy and send-proxy-v2 are mutually exclusive", data.Name))
+	}
+	// agent-addr and agent-port only take effect with agent-check
+	if (data.AgentAddr != "" || data.AgentPort != nil) && data.AgentCheck != "enabled" {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Server %s: agent-addr and agent-port require agent-check", data.Name))
+	}
+	if data.Source != "" && !validServerSource(data.Source) {
+		return NewConfError(ErrValidationError, fmt.Sprintf("Server %s: source %q is not <addr>[:<port>] [usesrc <addr>[:<port>]|client|clientip|hdr_ip(<hdr>[,<occ>])]", data.Name, data.Source))
+	}
+	return nil
+}
+
+// validServerSource checks the source option value of a server, an address
+// with an optional port or port range, optionally followed by usesrc
+func validServerSource(source string) bool {
+	fields := strings.Fields(source)
+	switch {
+	case len(fields) == 1:
+		return validSourceAddress(fields[0])
+	case len(fields) == 3 && fields[1] == "usesrc":
+		if !validSourceAddress(fields[0]) {
+			return false
+		}
+		usesrc := fields[2]
+		return usesrc == "client" || usesrc == "clientip" ||
+			(strings.HasPrefix(usesrc, "hdr_ip(") && strings.HasSuffix(usesrc, ")")) ||
+			validSourceAddress(usesrc)
+	default:
+		return false
+	}
+}
+
+// validSourceAddress checks an IPv4 or IPv6 address with an optional port or
+// port range, the port follows the last colon
+func validSourceAddress(address string) bool {
+	if net.ParseIP(address) != nil {
+		return true
+	}
+	host, port, ok := splitHostPort(address)
+	if !ok || net.ParseIP(host) == nil {
+		return false
+	}
+	for _, p := range strings.SplitN(port, "-", 2) {
+		if n, err := strconv.ParseInt(p, 10, 64); err != nil || n < 0 || n > 65535 {
+			return false
+		}
+	}
+	return true
+}
+
 func SerializeServer(s models.Server) types.Server { //nolint:gocognit,gocyclo
 	srv := types.Server{
-		Name:   s.Name,
-		Params: []params.ServerOption{},
+		Name:    s.Name,
+		Params:  []params.ServerOption{},
+		Comment: s.Comment,
 	}
 	if s.Port != nil {
 		srv.Address = s.Address + ":" + strconv.FormatInt(*s.Port, 10)
@@ -527,6 +838,9 @@ func SerializeServer(s models.Server) types.Server { //nolint:gocognit,gocyclo
 	if s.Weight != nil {
 		srv.Params = append(srv.Params, &params.ServerOptionValue{Name: "weight", Value: strconv.FormatInt(*s.Weight, 10)})
 	}
+	if s.ID != nil {
+		srv.Params = append(srv.Params, &params.ServerOptionValue{Name: "id", Value: strconv.FormatInt(*s.ID, 10)})
+	}
 	if s.InitAddr != nil {
 		srv.Params = append(srv.Params, &params.ServerOptionValue{Name: "init-addr", Value: *s.InitAddr})
 	}
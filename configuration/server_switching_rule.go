@@ -90,13 +90,17 @@ func (c *Client) DeleteServerSwitchingRule(id int64, backend string, transaction
 	return nil
 }
 
-// CreateServerSwitchingRule creates a server switching rule in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateServerSwitchingRule creates a server switching rule in configuration at data.Index, rules at
+// that position and after it are moved down, a nil index appends the rule. One of version
+// or transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateServerSwitchingRule(backend string, data *models.ServerSwitchingRule, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -104,8 +108,13 @@ func (c *Client) CreateServerSwitchingRule(backend string, data *models.ServerSw
 		return err
 	}
 
-	if err := p.Insert(parser.Backends, backend, "use-server", SerializeServerSwitchingRule(*data), int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), "backend", backend, t, transactionID == "", err)
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
+	if err := p.Insert(parser.Backends, backend, "use-server", SerializeServerSwitchingRule(*data), int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), "backend", backend, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -120,7 +129,10 @@ func (c *Client) EditServerSwitchingRule(id int64, backend string, data *models.
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -129,11 +141,11 @@ func (c *Client) EditServerSwitchingRule(id int64, backend string, data *models.
 	}
 
 	if _, err := p.GetOne(parser.Backends, backend, "use-server", int(id)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), "backend", backend, t, transactionID == "", err)
+		return c.HandleError(strconv.FormatInt(id, 10), "backend", backend, t, transactionID == "", err)
 	}
 
 	if err := p.Set(parser.Backends, backend, "use-server", SerializeServerSwitchingRule(*data), int(id)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), "backend", backend, t, transactionID == "", err)
+		return c.HandleError(strconv.FormatInt(id, 10), "backend", backend, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
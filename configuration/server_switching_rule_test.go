@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -189,3 +190,99 @@ func TestCreateEditDeleteServerSwitchingRule(t *testing.T) {
 		version++
 	}
 }
+
+func TestServerSwitchingRulesOrder(t *testing.T) {
+	err := client.CreateBackend(&models.Backend{Name: "server_switching_order"}, "", version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	rules := []*models.ServerSwitchingRule{
+		{Index: misc.Int64P(0), TargetServer: "srv_static", Cond: "if", CondTest: "{ path_beg /static }"},
+		{Index: misc.Int64P(1), TargetServer: "srv_api", Cond: "if", CondTest: "{ path_beg /api }"},
+		// inserted first, evaluated before the rules above
+		{Index: misc.Int64P(0), TargetServer: "srv_admin", Cond: "if", CondTest: "{ src 10.0.0.0/8 }"},
+	}
+	for _, r := range rules {
+		if err := client.CreateServerSwitchingRule("server_switching_order", r, "", version); err != nil {
+			t.Fatalf("%s: %s", r.TargetServer, err.Error())
+		}
+		version++
+	}
+
+	// without validation a rule without index is appended
+	client.UseValidation = false
+	err = client.CreateServerSwitchingRule("server_switching_order", &models.ServerSwitchingRule{TargetServer: "srv_default", Cond: "unless", CondTest: "FALSE"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	checkOrder := func(expected []string) {
+		t.Helper()
+		_, created, err := client.GetServerSwitchingRules("server_switching_order", "")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(created) != len(expected) {
+			t.Fatalf("%v server switching rules returned, expected %v", len(created), len(expected))
+		}
+		for i, r := range created {
+			if *r.Index != int64(i) {
+				t.Errorf("Rule %v has index %v", i, *r.Index)
+			}
+			if r.TargetServer != expected[i] {
+				t.Errorf("Rule %v targets %s, expected %s", i, r.TargetServer, expected[i])
+			}
+		}
+	}
+	checkOrder([]string{"srv_admin", "srv_static", "srv_api", "srv_default"})
+
+	// deleting a rule moves the following rules up
+	if err := client.DeleteServerSwitchingRule(1, "server_switching_order", "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+	checkOrder([]string{"srv_admin", "srv_api", "srv_default"})
+
+	if err := client.DeleteBackend("server_switching_order", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerSwitchingRuleMissingBackend(t *testing.T) {
+	transactions := len(client.GetParserTransactions())
+	sr := &models.ServerSwitchingRule{
+		Index:        misc.Int64P(0),
+		TargetServer: "webserv",
+		Cond:         "if",
+		CondTest:     "TRUE",
+	}
+
+	err := client.CreateServerSwitchingRule("missing_backend", sr, "", version)
+	if err == nil {
+		version++
+		t.Fatal("Should throw error, non existant backend")
+	}
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected parent does not exist error, got: %v", err)
+	}
+
+	err = client.EditServerSwitchingRule(0, "missing_backend", sr, "", version)
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected parent does not exist error, got: %v", err)
+	}
+
+	_, _, err = client.GetServerSwitchingRules("missing_backend", "")
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected parent does not exist error, got: %v", err)
+	}
+
+	if n := len(client.GetParserTransactions()); n != transactions {
+		t.Errorf("%v transactions left, expected %v", n, transactions)
+	}
+}
@@ -16,10 +16,16 @@
 package configuration
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/params"
+
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -253,3 +259,913 @@ func TestCreateEditDeleteServer(t *testing.T) {
 		version++
 	}
 }
+
+func TestParseServerCheckParams(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+backend check_params
+  server srv1 10.0.0.1:80 check inter 2s rise 3 fall 2 fastinter 500ms downinter 1m pool-purge-delay 10s
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("check_params", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 1 {
+		t.Fatalf("%v servers returned, expected 1", len(servers))
+	}
+	s := servers[0]
+	if s.Check != "enabled" {
+		t.Errorf("Check is %s, expected enabled", s.Check)
+	}
+	for name, v := range map[string]struct {
+		got      *int64
+		expected int64
+	}{
+		"inter":            {s.Inter, 2000},
+		"rise":             {s.Rise, 3},
+		"fall":             {s.Fall, 2},
+		"fastinter":        {s.Fastinter, 500},
+		"downinter":        {s.Downinter, 60000},
+		"pool-purge-delay": {s.PoolPurgeDelay, 10000},
+	} {
+		if v.got == nil {
+			t.Errorf("%s not parsed", name)
+		} else if *v.got != v.expected {
+			t.Errorf("%s is %v, expected %v", name, *v.got, v.expected)
+		}
+	}
+}
+
+func TestEditServerWeightKeepsCheckParams(t *testing.T) {
+	s := &models.Server{
+		Name:      "check_tuned",
+		Address:   "10.0.0.2",
+		Port:      misc.Int64P(8080),
+		Check:     "enabled",
+		Inter:     misc.Int64P(2000),
+		Rise:      misc.Int64P(3),
+		Fall:      misc.Int64P(2),
+		Fastinter: misc.Int64P(500),
+		Downinter: misc.Int64P(60000),
+		Weight:    misc.Int64P(10),
+	}
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("check_tuned", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	created.Weight = misc.Int64P(50)
+	if err := client.EditServer("check_tuned", "test", created, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, edited, err := client.GetServer("check_tuned", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if *edited.Weight != 50 {
+		t.Errorf("Weight is %v, expected 50", *edited.Weight)
+	}
+	edited.Weight = s.Weight
+	if !reflect.DeepEqual(edited, s) {
+		fmt.Printf("Edited server: %v\n", edited)
+		fmt.Printf("Created server: %v\n", s)
+		t.Error("Editing the weight changed other server parameters")
+	}
+
+	if err := client.DeleteServer("check_tuned", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerTLSRoundTrip(t *testing.T) {
+	line := "server s1 10.0.0.1:443 ssl verify required sni req.hdr(host) ca-file /etc/ssl/ca.pem ciphers ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384"
+	p := &parser.Parser{}
+	if err := p.ParseData("backend tls\n  " + line + "\n"); err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("tls", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 1 {
+		t.Fatalf("%v servers returned, expected 1", len(servers))
+	}
+	s := servers[0]
+	if s.Ssl != "enabled" || s.Verify != "required" || s.Sni != "req.hdr(host)" || s.SslCafile != "/etc/ssl/ca.pem" ||
+		s.Ciphers != "ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384" {
+		t.Errorf("TLS options not parsed: %v", *s)
+	}
+
+	if err := p.Set(parser.Backends, "tls", "server", SerializeServer(*s), 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	serialized, err := ParseServers("tls", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(serialized[0], s) {
+		fmt.Printf("Serialized server: %v\n", serialized[0])
+		fmt.Printf("Parsed server: %v\n", s)
+		t.Error("TLS server changed after a serialization round trip")
+	}
+
+	// server verify only knows none and required, optional is a bind setting
+	s.Verify = "optional"
+	if err := client.CreateServer("test", s, "", version); err == nil {
+		version++
+		t.Error("Should throw validation error, invalid verify")
+	}
+}
+
+func TestServerSendProxy(t *testing.T) {
+	s := &models.Server{
+		Name:           "proxy_v2",
+		Address:        "10.0.0.3",
+		Port:           misc.Int64P(8080),
+		SendProxyV2:    "enabled",
+		ProxyV2Options: []string{"ssl", "unique-id"},
+	}
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("proxy_v2", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, s) {
+		fmt.Printf("Created server: %v\n", created)
+		fmt.Printf("Given server: %v\n", s)
+		t.Error("Created server not equal to given server")
+	}
+
+	// switching to v1 requires v2 to be unset
+	created.SendProxy = "enabled"
+	err = client.EditServer("proxy_v2", "test", created, "", version)
+	if err == nil {
+		version++
+		t.Error("Should throw validation error, send-proxy and send-proxy-v2 both set")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+
+	created.SendProxyV2 = ""
+	created.ProxyV2Options = nil
+	if err := client.EditServer("proxy_v2", "test", created, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, edited, err := client.GetServer("proxy_v2", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if edited.SendProxy != "enabled" || edited.SendProxyV2 != "" {
+		t.Errorf("send-proxy %s and send-proxy-v2 %s, expected only send-proxy", edited.SendProxy, edited.SendProxyV2)
+	}
+
+	if err := client.DeleteServer("proxy_v2", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestEditServerKeepsCookie(t *testing.T) {
+	s := &models.Server{
+		Name:    "sticky",
+		Address: "10.0.0.4",
+		Port:    misc.Int64P(8080),
+		Check:   "enabled",
+		Weight:  misc.Int64P(20),
+		Cookie:  "sticky",
+	}
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("sticky", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	created.Maxconn = misc.Int64P(500)
+	if err := client.EditServer("sticky", "test", created, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, edited, err := client.GetServer("sticky", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if edited.Cookie != "sticky" || edited.Check != "enabled" || edited.Weight == nil || *edited.Weight != 20 {
+		t.Errorf("Server is %v, expected cookie, check and weight unchanged", *edited)
+	}
+	if edited.Maxconn == nil || *edited.Maxconn != 500 {
+		t.Error("Maxconn not edited")
+	}
+
+	if err := client.DeleteServer("sticky", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerResolvers(t *testing.T) {
+	s := &models.Server{
+		Name:          "dns_discovered",
+		Address:       "api.svc",
+		Port:          misc.Int64P(8080),
+		Resolvers:     "server_dns",
+		ResolvePrefer: "ipv4",
+		ResolveNet:    "10.0.0.0/8",
+		InitAddr:      misc.StringP("none"),
+	}
+
+	// the resolvers section has to exist first
+	err := client.CreateServer("test", s, "", version)
+	if err == nil {
+		version++
+		t.Fatal("Should throw validation error, resolvers section does not exist")
+	}
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+
+	tr, err := client.StartTransaction(version)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := client.CreateResolver(&models.Resolver{Name: "server_dns"}, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := client.CreateNameserver("server_dns", &models.Nameserver{Name: "ns1", Address: misc.StringP("10.0.0.53"), Port: misc.Int64P(53)}, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := client.CreateServer("test", s, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := client.CommitTransaction(tr.ID); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("dns_discovered", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, s) {
+		fmt.Printf("Created server: %v\n", created)
+		fmt.Printf("Given server: %v\n", s)
+		t.Error("Created server not equal to given server")
+	}
+
+	s.ResolvePrefer = "ipv5"
+	if err := client.EditServer("dns_discovered", "test", s, "", version); err == nil {
+		version++
+		t.Error("Should throw validation error, invalid resolve-prefer")
+	}
+
+	if err := client.DeleteServer("dns_discovered", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+	if err := client.DeleteResolver("server_dns", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerMaintenance(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+backend admin_state
+  server srv_disabled 10.0.0.1:80 disabled
+  server srv_enabled 10.0.0.2:80 enabled
+  server srv_default 10.0.0.3:80
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("admin_state", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := map[string]string{
+		"srv_disabled": "enabled",
+		"srv_enabled":  "disabled",
+		"srv_default":  "",
+	}
+	if len(servers) != len(expected) {
+		t.Fatalf("%v servers returned, expected %v", len(servers), len(expected))
+	}
+	for _, s := range servers {
+		if s.Maintenance != expected[s.Name] {
+			t.Errorf("%s: maintenance is %s, expected %s", s.Name, s.Maintenance, expected[s.Name])
+		}
+	}
+
+	for maintenance, keyword := range map[string]string{"enabled": "disabled", "disabled": "enabled", "": ""} {
+		srv := SerializeServer(models.Server{Name: "srv", Address: "10.0.0.1", Maintenance: maintenance})
+		var words []string
+		for _, param := range srv.Params {
+			if w, ok := param.(*params.ServerOptionWord); ok && (w.Name == "disabled" || w.Name == "enabled") {
+				words = append(words, w.Name)
+			}
+		}
+		if keyword == "" && len(words) != 0 {
+			t.Errorf("maintenance %q: serialized %v, expected no state keyword", maintenance, words)
+		}
+		if keyword != "" && (len(words) != 1 || words[0] != keyword) {
+			t.Errorf("maintenance %q: serialized %v, expected only %s", maintenance, words, keyword)
+		}
+	}
+
+	s := &models.Server{
+		Name:        "drained",
+		Address:     "10.0.0.5",
+		Port:        misc.Int64P(8080),
+		Maintenance: "enabled",
+	}
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	for _, state := range []string{"disabled", "enabled", ""} {
+		s.Maintenance = state
+		if err := client.EditServer("drained", "test", s, "", version); err != nil {
+			t.Fatal(err.Error())
+		}
+		version++
+
+		_, edited, err := client.GetServer("drained", "test", "")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if edited.Maintenance != state {
+			t.Errorf("Maintenance is %q, expected %q", edited.Maintenance, state)
+		}
+	}
+
+	if err := client.DeleteServer("drained", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerCapacityParams(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+backend capacity
+  server s1 10.0.0.1:80 maxconn 200 minconn 20 maxqueue 100 slowstart 60s
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("capacity", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 1 {
+		t.Fatalf("%v servers returned, expected 1", len(servers))
+	}
+	parsed := servers[0]
+	for name, v := range map[string]struct {
+		got      *int64
+		expected int64
+	}{
+		"maxconn":   {parsed.Maxconn, 200},
+		"minconn":   {parsed.Minconn, 20},
+		"maxqueue":  {parsed.Maxqueue, 100},
+		"slowstart": {parsed.Slowstart, 60000},
+	} {
+		if v.got == nil {
+			t.Errorf("%s not parsed", name)
+		} else if *v.got != v.expected {
+			t.Errorf("%s is %v, expected %v", name, *v.got, v.expected)
+		}
+	}
+
+	parsed.Port = misc.Int64P(8080)
+	if err := client.CreateServer("test", parsed, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("s1", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, parsed) {
+		t.Errorf("Created server %v not equal to given server %v", *created, *parsed)
+	}
+
+	negative := *created
+	negative.Maxqueue = misc.Int64P(-1)
+	err = client.EditServer("s1", "test", &negative, "", version)
+	if err == nil {
+		version++
+		t.Error("Should throw validation error, maxqueue is negative")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+
+	if err := client.DeleteServer("s1", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerTrack(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+backend tracking
+  server s2 10.0.0.2:80 backup track be1/s1
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("tracking", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 1 {
+		t.Fatalf("%v servers returned, expected 1", len(servers))
+	}
+	if servers[0].Track != "be1/s1" {
+		t.Errorf("Track is %s, expected be1/s1", servers[0].Track)
+	}
+
+	s := &models.Server{
+		Name:    "mirror",
+		Address: "10.0.0.6",
+		Port:    misc.Int64P(8080),
+		Backup:  "enabled",
+		Track:   "webserv",
+	}
+	err = client.CreateServer("test", s, "", version)
+	if err == nil {
+		version++
+		t.Fatal("Should throw validation error, track has no backend")
+	}
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+
+	s.Track = "test/webserv"
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("mirror", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, s) {
+		t.Errorf("Created server %v not equal to given server %v", *created, *s)
+	}
+
+	if err := client.DeleteServer("mirror", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerID(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+frontend identified
+  bind 10.0.0.1:80 name public id 3
+backend identified
+  server srv1 10.0.0.1:80 id 12
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("identified", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 1 {
+		t.Fatalf("%v servers returned, expected 1", len(servers))
+	}
+	if servers[0].Name != "srv1" || servers[0].ID == nil || *servers[0].ID != 12 {
+		t.Errorf("Server parsed as %s/%v, expected srv1/12", servers[0].Name, servers[0].ID)
+	}
+	binds, err := ParseBinds("identified", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(binds) != 1 || binds[0].Name != "public" || binds[0].ID == nil || *binds[0].ID != 3 {
+		t.Errorf("Bind parsed as %v, expected public/3", binds)
+	}
+
+	s := &models.Server{
+		Name:    "identified",
+		Address: "10.0.0.7",
+		Port:    misc.Int64P(8080),
+		ID:      misc.Int64P(0),
+	}
+	err = client.CreateServer("test", s, "", version)
+	if err == nil {
+		version++
+		t.Fatal("Should throw validation error, server id must be positive")
+	}
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+
+	s.ID = misc.Int64P(42)
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("identified", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, s) {
+		t.Errorf("Created server %v not equal to given server %v", *created, *s)
+	}
+
+	if err := client.DeleteServer("identified", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestEditServers(t *testing.T) {
+	path := "/tmp/haproxy-edit-servers.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, servers, indexes, err := c.GetServersByBackend("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != len(indexes) {
+		t.Fatalf("%v indexes returned for %v servers", len(indexes), len(servers))
+	}
+	for name, i := range indexes {
+		if servers[i].Name != name {
+			t.Errorf("Index %v of server %s points to %s", i, name, servers[i].Name)
+		}
+	}
+	if _, _, _, err := c.GetServersByBackend("doesnotexist", ""); err == nil {
+		t.Error("Should throw error, backend does not exist")
+	}
+
+	webserv := *servers[indexes["webserv"]]
+	webserv.Weight = misc.Int64P(50)
+	webserv2 := *servers[indexes["webserv2"]]
+	webserv2.Weight = misc.Int64P(60)
+
+	// a missing server fails the whole batch
+	err = c.EditServers("test", map[string]*models.Server{"webserv": &webserv, "missing": &webserv2}, "", v)
+	if err == nil {
+		t.Fatal("Should throw error, server missing does not exist")
+	}
+	if _, server, _ := c.GetServer("webserv", "test", ""); *server.Weight == 50 {
+		t.Error("Server webserv edited by a failed batch")
+	}
+
+	// renaming onto a kept server fails
+	renamed := webserv
+	renamed.Name = "webserv2"
+	if err := c.EditServers("test", map[string]*models.Server{"webserv": &renamed}, "", v); err == nil {
+		t.Fatal("Should throw error, server webserv2 already exists")
+	}
+
+	if err := c.EditServers("test", map[string]*models.Server{"webserv": &webserv, "webserv2": &webserv2}, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	nv, edited, _, err := c.GetServersByBackend("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if nv != v+1 {
+		t.Errorf("Version %v returned, expected %v", nv, v+1)
+	}
+	if !reflect.DeepEqual(edited[indexes["webserv"]], &webserv) || !reflect.DeepEqual(edited[indexes["webserv2"]], &webserv2) {
+		t.Errorf("Edited servers %v, expected %v and %v", edited, webserv, webserv2)
+	}
+}
+
+// prepareBenchmarkServers returns a client for a configuration with a
+// backend of n servers
+func TestWalkServers(t *testing.T) {
+	_, backends, err := client.GetBackends("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	walked := map[string]models.Servers{}
+	if _, err := client.WalkServers(context.Background(), "", func(backend string, server *models.Server) error {
+		walked[backend] = append(walked[backend], server)
+		return nil
+	}); err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, backend := range backends {
+		_, servers, err := client.GetServers(backend.Name, "")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(servers) == 0 {
+			servers = nil
+		}
+		if !reflect.DeepEqual(walked[backend.Name], servers) {
+			t.Errorf("Servers of backend %s walked as %v, expected %v", backend.Name, walked[backend.Name], servers)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.WalkServers(ctx, "", func(string, *models.Server) error { return nil }); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func prepareBenchmarkServers(b *testing.B, path string, n int) *Client {
+	b.Helper()
+	var sb strings.Builder
+	sb.WriteString("# _version=1\nbackend bench\n  mode http\n  balance roundrobin\n")
+	for i := 0; i < n; i++ {
+		sb.WriteString(fmt.Sprintf("  server srv%d 10.0.%d.%d:8080 check inter 2s weight 10 maxconn 1000\n", i, i/256, i%256))
+	}
+	if err := prepareTestFile(sb.String(), path); err != nil {
+		b.Fatal(err)
+	}
+	c, err := prepareClient(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return c
+}
+
+func BenchmarkGetServersByBackend(b *testing.B) {
+	path := "/tmp/haproxy-bench-servers.cfg"
+	c := prepareBenchmarkServers(b, path, 200)
+	defer func() { _ = deleteTestFile(path) }()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, servers, indexes, err := c.GetServersByBackend("bench", "")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if servers[indexes["srv199"]].Name != "srv199" {
+			b.Fatal("srv199 not found")
+		}
+	}
+}
+
+func BenchmarkEditServers(b *testing.B) {
+	path := "/tmp/haproxy-bench-edit-servers.cfg"
+	c := prepareBenchmarkServers(b, path, 200)
+	defer func() { _ = deleteTestFile(path) }()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, servers, _, err := c.GetServersByBackend("bench", "")
+		if err != nil {
+			b.Fatal(err)
+		}
+		edits := make(map[string]*models.Server, len(servers))
+		for _, s := range servers {
+			s.Weight = misc.Int64P(i%256 + 1)
+			edits[s.Name] = s
+		}
+		if err := c.EditServers("bench", edits, "", v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestServerSource(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+backend egress
+  server srv1 10.0.0.1:80 source 10.1.2.3
+  server srv2 10.0.0.2:80 source 10.1.2.3:1024-2048 usesrc clientip check
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("egress", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 2 {
+		t.Fatalf("%v servers returned, expected 2", len(servers))
+	}
+	if servers[0].Source != "10.1.2.3" {
+		t.Errorf("Source is %q, expected 10.1.2.3", servers[0].Source)
+	}
+	if servers[1].Source != "10.1.2.3:1024-2048 usesrc clientip" || servers[1].Check != "enabled" {
+		t.Errorf("Source is %q, expected 10.1.2.3:1024-2048 usesrc clientip", servers[1].Source)
+	}
+
+	s := &models.Server{
+		Name:    "egress",
+		Address: "10.0.0.8",
+		Port:    misc.Int64P(8080),
+		Source:  "10.1.2.300",
+	}
+	for _, source := range []string{"10.1.2.300", "10.1.2.3:70000", "10.1.2.3 usesrc", "10.1.2.3 client"} {
+		s.Source = source
+		err = client.CreateServer("test", s, "", version)
+		if err == nil {
+			version++
+			t.Fatalf("Should throw validation error, source %q is invalid", source)
+		}
+		if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+			t.Errorf("Expected validation error for source %q, got: %v", source, err)
+		}
+	}
+
+	s.Source = "10.1.2.3 usesrc client"
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("egress", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, s) {
+		t.Errorf("Created server %v not equal to given server %v", *created, *s)
+	}
+
+	s.Source = "2001:db8::10"
+	if err := client.EditServer("egress", "test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+	if _, edited, err := client.GetServer("egress", "test", ""); err != nil || edited.Source != s.Source {
+		t.Errorf("Edited server source %v, expected %s: %v", edited, s.Source, err)
+	}
+
+	if err := client.DeleteServer("egress", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerFailoverActions(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+backend failover
+  server srv1 10.0.0.1:80 check observe layer7 on-error sudden-death on-marked-down shutdown-sessions on-marked-up shutdown-backup-sessions
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("failover", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 1 {
+		t.Fatalf("%v servers returned, expected 1", len(servers))
+	}
+	s := servers[0]
+	if s.OnError != "sudden-death" || s.OnMarkedDown != "shutdown-sessions" || s.OnMarkedUp != "shutdown-backup-sessions" {
+		t.Errorf("Failover actions parsed as %s/%s/%s", s.OnError, s.OnMarkedDown, s.OnMarkedUp)
+	}
+	if got := params.ServerOptionsString(SerializeServer(*s).Params); !strings.Contains(got, "on-error sudden-death on-marked-down shutdown-sessions on-marked-up shutdown-backup-sessions") {
+		t.Errorf("Failover actions serialized as %s", got)
+	}
+
+	s.Name = "failover"
+	for field, invalid := range map[string]*models.Server{
+		"on-error":       {Name: "failover", Address: "10.0.0.9", OnError: "restart"},
+		"on-marked-down": {Name: "failover", Address: "10.0.0.9", OnMarkedDown: "shutdown-backup-sessions"},
+		"on-marked-up":   {Name: "failover", Address: "10.0.0.9", OnMarkedUp: "shutdown-sessions"},
+	} {
+		err = client.CreateServer("test", invalid, "", version)
+		if err == nil {
+			version++
+			t.Fatalf("Should throw validation error, invalid %s", field)
+		}
+		if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+			t.Errorf("Expected validation error for %s, got: %v", field, err)
+		}
+	}
+
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("failover", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, s) {
+		t.Errorf("Created server %v not equal to given server %v", *created, *s)
+	}
+
+	// edits of other options keep the actions
+	created.Weight = misc.Int64P(30)
+	if err := client.EditServer("failover", "test", created, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+	_, edited, err := client.GetServer("failover", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if edited.OnError != s.OnError || edited.OnMarkedDown != s.OnMarkedDown || edited.OnMarkedUp != s.OnMarkedUp {
+		t.Errorf("Edited server lost its failover actions: %v", *edited)
+	}
+
+	if err := client.DeleteServer("failover", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
+
+func TestServerAgentCheck(t *testing.T) {
+	p := &parser.Parser{}
+	err := p.ParseData(`
+backend agent
+  server srv1 10.0.0.1:80 check agent-check agent-addr 10.0.0.9 agent-port 9000 agent-inter 5s agent-send ready
+`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servers, err := ParseServers("agent", p)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(servers) != 1 {
+		t.Fatalf("%v servers returned, expected 1", len(servers))
+	}
+	s := servers[0]
+	if s.AgentCheck != "enabled" || s.AgentAddr != "10.0.0.9" || s.AgentSend != "ready" {
+		t.Errorf("Agent check parsed as %s/%s/%s", s.AgentCheck, s.AgentAddr, s.AgentSend)
+	}
+	if s.AgentPort == nil || *s.AgentPort != 9000 {
+		t.Errorf("agent-port is %v, expected 9000", s.AgentPort)
+	}
+	if s.AgentInter == nil || *s.AgentInter != 5000 {
+		t.Errorf("agent-inter is %v, expected 5000", s.AgentInter)
+	}
+
+	s.Name = "agent"
+	s.AgentCheck = ""
+	err = client.CreateServer("test", s, "", version)
+	if err == nil {
+		version++
+		t.Fatal("Should throw validation error, agent-addr without agent-check")
+	}
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+
+	s.AgentCheck = "enabled"
+	if err := client.CreateServer("test", s, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetServer("agent", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created, s) {
+		t.Errorf("Created server %v not equal to given server %v", *created, *s)
+	}
+
+	if err := client.DeleteServer("agent", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
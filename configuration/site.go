@@ -82,7 +82,10 @@ func (c *Client) CreateSite(data *models.Site, transactionID string, version int
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	// start an implicit transaction for create site (multiple operations required) if not already given
@@ -160,7 +163,10 @@ func (c *Client) EditSite(name string, data *models.Site, transactionID string,
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
 		}
 	}
 	// start an implicit transaction for create site (multiple operations required) if not already given
@@ -0,0 +1,169 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"fmt"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// Snapshot is a read-only view of the committed configuration. All sections are
+// parsed once when the snapshot is taken and the getters serve them from memory.
+// The returned objects are shared between callers and must not be modified.
+type Snapshot struct {
+	version   int64
+	conf      *models.Configuration
+	frontends map[string]*models.ConfigurationFrontend
+	backends  map[string]*models.ConfigurationBackend
+}
+
+// Snapshot returns a read-only view of the committed configuration. The same
+// snapshot is returned until the next commit, take a new one after committing
+// to see the changes.
+func (c *Client) Snapshot() (*Snapshot, error) {
+	c.parsersMu.RLock()
+	s := c.snapshot
+	p := c.Parser
+	generation := c.generation
+	c.parsersMu.RUnlock()
+	if s != nil {
+		return s, nil
+	}
+
+	conf, err := ParseStructuredConfiguration(p)
+	if err != nil {
+		return nil, err
+	}
+	s = &Snapshot{
+		version:   parserVersion(p),
+		conf:      conf,
+		frontends: make(map[string]*models.ConfigurationFrontend, len(conf.Frontends)),
+		backends:  make(map[string]*models.ConfigurationBackend, len(conf.Backends)),
+	}
+	for _, f := range conf.Frontends {
+		s.frontends[f.Frontend.Name] = f
+	}
+	for _, b := range conf.Backends {
+		s.backends[b.Backend.Name] = b
+	}
+
+	c.parsersMu.Lock()
+	// keep it only if Parser did not change meanwhile
+	if c.generation == generation {
+		c.snapshot = s
+	}
+	c.parsersMu.Unlock()
+	return s, nil
+}
+
+// Version returns the configuration version the snapshot was taken at
+func (s *Snapshot) Version() int64 {
+	return s.version
+}
+
+// Configuration returns the whole configuration of the snapshot
+func (s *Snapshot) Configuration() *models.Configuration {
+	return s.conf
+}
+
+// GetFrontends returns configuration version and an array of configured frontends
+func (s *Snapshot) GetFrontends() (int64, models.Frontends, error) {
+	frontends := models.Frontends{}
+	for _, f := range s.conf.Frontends {
+		frontends = append(frontends, f.Frontend)
+	}
+	return s.version, frontends, nil
+}
+
+// GetFrontend returns configuration version and a requested frontend.
+// Returns error if frontend does not exist.
+func (s *Snapshot) GetFrontend(name string) (int64, *models.Frontend, error) {
+	f, ok := s.frontends[name]
+	if !ok {
+		return s.version, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Frontend %s does not exist", name))
+	}
+	return s.version, f.Frontend, nil
+}
+
+// GetBackends returns configuration version and an array of configured backends
+func (s *Snapshot) GetBackends() (int64, models.Backends, error) {
+	backends := models.Backends{}
+	for _, b := range s.conf.Backends {
+		backends = append(backends, b.Backend)
+	}
+	return s.version, backends, nil
+}
+
+// GetBackend returns configuration version and a requested backend.
+// Returns error if backend does not exist.
+func (s *Snapshot) GetBackend(name string) (int64, *models.Backend, error) {
+	b, ok := s.backends[name]
+	if !ok {
+		return s.version, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Backend %s does not exist", name))
+	}
+	return s.version, b.Backend, nil
+}
+
+// GetBinds returns configuration version and an array of configured binds
+// in the specified frontend. Returns error if frontend does not exist.
+func (s *Snapshot) GetBinds(frontend string) (int64, models.Binds, error) {
+	f, ok := s.frontends[frontend]
+	if !ok {
+		return s.version, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Frontend %s does not exist", frontend))
+	}
+	return s.version, f.Binds, nil
+}
+
+// GetBind returns configuration version and a requested bind in the specified
+// frontend. Returns error if frontend or bind does not exist.
+func (s *Snapshot) GetBind(name string, frontend string) (int64, *models.Bind, error) {
+	v, binds, err := s.GetBinds(frontend)
+	if err != nil {
+		return v, nil, err
+	}
+	for _, b := range binds {
+		if b.Name == name {
+			return v, b, nil
+		}
+	}
+	return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Bind %s does not exist in frontend %s", name, frontend))
+}
+
+// GetServers returns configuration version and an array of configured servers
+// in the specified backend. Returns error if backend does not exist.
+func (s *Snapshot) GetServers(backend string) (int64, models.Servers, error) {
+	b, ok := s.backends[backend]
+	if !ok {
+		return s.version, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Backend %s does not exist", backend))
+	}
+	return s.version, b.Servers, nil
+}
+
+// GetServer returns configuration version and a requested server in the specified
+// backend. Returns error if backend or server does not exist.
+func (s *Snapshot) GetServer(name string, backend string) (int64, *models.Server, error) {
+	v, servers, err := s.GetServers(backend)
+	if err != nil {
+		return v, nil, err
+	}
+	for _, srv := range servers {
+		if srv.Name == name {
+			return v, srv, nil
+		}
+	}
+	return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("Server %s does not exist in backend %s", name, backend))
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestSnapshot(t *testing.T) {
+	path := "/tmp/haproxy-snapshot.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	s, err := c.Snapshot()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if again, _ := c.Snapshot(); again != s {
+		t.Error("Snapshot taken again without a commit")
+	}
+
+	v, binds, err := c.GetBinds("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	sv, sBinds, err := s.GetBinds("test")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if sv != v || !reflect.DeepEqual(sBinds, binds) {
+		t.Errorf("Snapshot binds %v at version %v, expected %v at version %v", sBinds, sv, binds, v)
+	}
+	_, servers, err := c.GetServers("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	_, sServers, err := s.GetServers("test")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(sServers, servers) {
+		t.Errorf("Snapshot servers %v, expected %v", sServers, servers)
+	}
+	_, server, err := c.GetServer("webserv", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, sServer, err := s.GetServer("webserv", "test"); err != nil || !reflect.DeepEqual(sServer, server) {
+		t.Errorf("Snapshot server %v, expected %v: %v", sServer, server, err)
+	}
+
+	if _, _, err := s.GetServers("doesnotexist"); err == nil {
+		t.Error("Should throw error, backend does not exist")
+	} else if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrParentDoesNotExist {
+		t.Errorf("Expected parent does not exist error, got: %v", err)
+	}
+	if _, _, err := s.GetBind("doesnotexist", "test"); err == nil {
+		t.Error("Should throw error, bind does not exist")
+	}
+
+	// a commit drops the snapshot, the old one stays as it was
+	srv := &models.Server{Name: "snapshot", Address: "10.0.0.7", Port: misc.Int64P(80)}
+	if err := c.CreateServer("test", srv, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, _, err := s.GetServer("snapshot", "test"); err == nil {
+		t.Error("Server created after the snapshot found in it")
+	}
+	committed, err := c.Snapshot()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if committed == s || committed.Version() != v+1 {
+		t.Fatalf("Snapshot at version %v after commit, expected %v", committed.Version(), v+1)
+	}
+	if _, _, err := committed.GetServer("snapshot", "test"); err != nil {
+		t.Error(err.Error())
+	}
+
+	// changes made in place of the parser drop the snapshot too
+	if err := c.IncrementVersion(); err != nil {
+		t.Fatal(err.Error())
+	}
+	incremented, err := c.Snapshot()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if incremented.Version() != v+2 {
+		t.Errorf("Snapshot at version %v after IncrementVersion, expected %v", incremented.Version(), v+2)
+	}
+	if err := c.LoadData(path); err != nil {
+		t.Fatal(err.Error())
+	}
+	if reloaded, _ := c.Snapshot(); reloaded == incremented {
+		t.Error("Snapshot kept after the configuration was reloaded")
+	}
+}
+
+func BenchmarkGetServers(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, _, err := client.GetServers("test", ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnapshotGetServers(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s, err := client.Snapshot()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, _, err := s.GetServers("test"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/haproxytech/client-native/v2/misc"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -235,3 +236,84 @@ func TestCreateEditDeleteStickRule(t *testing.T) {
 		version++
 	}
 }
+
+func TestStickTableWithStickRules(t *testing.T) {
+	b := &models.Backend{
+		Name: "stick_rate_limit",
+		StickTable: &models.BackendStickTable{
+			Type:   "ip",
+			Size:   misc.Int64P(1048576),
+			Expire: misc.Int64P(30000),
+			Store:  "gpc0,conn_rate(10s)",
+		},
+	}
+	if err := client.CreateBackend(b, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetBackend("stick_rate_limit", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(created.StickTable, b.StickTable) {
+		t.Errorf("Created stick table %v, expected %v", *created.StickTable, *b.StickTable)
+	}
+
+	// without validation a rule without index is appended
+	client.UseValidation = false
+	err = client.CreateStickRule("stick_rate_limit", &models.StickRule{Type: "on", Pattern: "src"}, "", version)
+	client.UseValidation = true
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	sr := &models.StickRule{Index: misc.Int64P(0), Type: "match", Pattern: "src", Cond: "if", CondTest: "{ src_conn_rate gt 10 }"}
+	if err := client.CreateStickRule("stick_rate_limit", sr, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, rules, err := client.GetStickRules("stick_rate_limit", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(rules) != 2 {
+		t.Fatalf("%v stick rules returned, expected 2", len(rules))
+	}
+	if !reflect.DeepEqual(rules[0], sr) {
+		t.Errorf("Stick rule 0 is %v, expected %v", *rules[0], *sr)
+	}
+	if *rules[1].Index != 1 || rules[1].Type != "on" || rules[1].Pattern != "src" {
+		t.Errorf("Stick rule 1 is %v, expected stick on src", *rules[1])
+	}
+
+	// removing the table leaves the rules in place
+	b.StickTable = nil
+	if err := client.EditBackend("stick_rate_limit", b, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err = client.GetBackend("stick_rate_limit", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if created.StickTable != nil {
+		t.Errorf("Stick table %v still exists", *created.StickTable)
+	}
+	_, rules, err = client.GetStickRules("stick_rate_limit", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(rules) != 2 {
+		t.Errorf("%v stick rules returned, expected 2", len(rules))
+	}
+
+	if err := client.DeleteBackend("stick_rate_limit", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
@@ -0,0 +1,574 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/common"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetStructuredConfiguration returns configuration version and the whole configuration
+// with all sections and the objects they contain. All sections are read from the
+// same parser. Returns error on fail.
+func (c *Client) GetStructuredConfiguration(transactionID string) (int64, *models.Configuration, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	conf, err := ParseStructuredConfiguration(p)
+	if err != nil {
+		return v, nil, err
+	}
+
+	return v, conf, nil
+}
+
+// PushStructuredConfiguration replaces the global and defaults sections and all frontends,
+// backends, resolvers and peers sections with the ones in data, sections of other types are
+// left as they are. Lines of existing sections the structured configuration has no model
+// for are kept. Nothing is changed if any section can not be serialized. One of version
+// or transactionID is mandatory. Returns error on fail, nil on success.
+func (c *Client) PushStructuredConfiguration(data *models.Configuration, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		if !c.SkipSemanticValidation {
+			if err := validateStructuredConfiguration(data); err != nil {
+				return err
+			}
+		}
+	}
+
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	// an implicit transaction is deleted on error, an explicit one has to be restored
+	backup := p.String()
+	if err := SerializeStructuredConfiguration(p, data); err != nil {
+		if transactionID != "" {
+			_ = p.ParseData(backup)
+		}
+		return c.HandleError("", "", "", t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseStructuredConfiguration parses all sections of the configuration
+// loaded in the parser. Sections of each type are sorted by name so the
+// result can be compared between calls.
+func ParseStructuredConfiguration(p *parser.Parser) (*models.Configuration, error) {
+	conf := &models.Configuration{
+		Frontends:    []*models.ConfigurationFrontend{},
+		Backends:     []*models.ConfigurationBackend{},
+		Resolvers:    []*models.ConfigurationResolver{},
+		PeerSections: []*models.ConfigurationPeerSection{},
+	}
+
+	g, err := ParseGlobalSection(p)
+	if err != nil {
+		return nil, err
+	}
+	conf.Global = g
+
+	d := &models.Defaults{}
+	_ = ParseSection(d, parser.Defaults, parser.DefaultSectionName, p)
+	conf.Defaults = d
+
+	names, err := p.SectionsGet(parser.Frontends)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f, err := parseStructuredFrontend(name, p)
+		if err != nil {
+			return nil, err
+		}
+		conf.Frontends = append(conf.Frontends, f)
+	}
+
+	names, err = p.SectionsGet(parser.Backends)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b, err := parseStructuredBackend(name, p)
+		if err != nil {
+			return nil, err
+		}
+		conf.Backends = append(conf.Backends, b)
+	}
+
+	names, err = p.SectionsGet(parser.Resolvers)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r := &models.ConfigurationResolver{Resolver: &models.Resolver{Name: name}}
+		if err := ParseResolverSection(p, r.Resolver); err != nil {
+			return nil, err
+		}
+		if r.Nameservers, err = ParseNameservers(name, p); err != nil {
+			return nil, err
+		}
+		conf.Resolvers = append(conf.Resolvers, r)
+	}
+
+	names, err = p.SectionsGet(parser.Peers)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ps := &models.ConfigurationPeerSection{PeerSection: &models.PeerSection{Name: name}}
+		if ps.PeerEntries, err = ParsePeerEntries(name, p); err != nil {
+			return nil, err
+		}
+		conf.PeerSections = append(conf.PeerSections, ps)
+	}
+
+	return conf, nil
+}
+
+func parseStructuredFrontend(name string, p *parser.Parser) (*models.ConfigurationFrontend, error) {
+	var err error
+	f := &models.ConfigurationFrontend{Frontend: &models.Frontend{Name: name}}
+	if err = ParseSection(f.Frontend, parser.Frontends, name, p); err != nil {
+		return nil, err
+	}
+	if f.Binds, err = ParseBinds(name, p); err != nil {
+		return nil, err
+	}
+	if f.Captures, err = ParseCaptures(name, p); err != nil {
+		return nil, err
+	}
+	if f.Acls, err = ParseACLs("frontend", name, p); err != nil {
+		return nil, err
+	}
+	if f.HTTPRequestRules, err = ParseHTTPRequestRules("frontend", name, p); err != nil {
+		return nil, err
+	}
+	if f.HTTPResponseRules, err = ParseHTTPResponseRules("frontend", name, p); err != nil {
+		return nil, err
+	}
+	if f.TCPRequestRules, err = ParseTCPRequestRules("frontend", name, p); err != nil {
+		return nil, err
+	}
+	if f.BackendSwitchingRules, err = ParseBackendSwitchingRules(name, p); err != nil {
+		return nil, err
+	}
+	if f.Filters, err = ParseFilters("frontend", name, p); err != nil {
+		return nil, err
+	}
+	if f.LogTargets, err = ParseLogTargets("frontend", name, p); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func parseStructuredBackend(name string, p *parser.Parser) (*models.ConfigurationBackend, error) {
+	var err error
+	b := &models.ConfigurationBackend{Backend: &models.Backend{Name: name}}
+	if err = ParseSection(b.Backend, parser.Backends, name, p); err != nil {
+		return nil, err
+	}
+	if b.Servers, err = ParseServers(name, p); err != nil {
+		return nil, err
+	}
+	if b.Acls, err = ParseACLs("backend", name, p); err != nil {
+		return nil, err
+	}
+	if b.HTTPRequestRules, err = ParseHTTPRequestRules("backend", name, p); err != nil {
+		return nil, err
+	}
+	if b.HTTPResponseRules, err = ParseHTTPResponseRules("backend", name, p); err != nil {
+		return nil, err
+	}
+	if b.TCPRequestRules, err = ParseTCPRequestRules("backend", name, p); err != nil {
+		return nil, err
+	}
+	if b.TCPResponseRules, err = ParseTCPResponseRules(name, p); err != nil {
+		return nil, err
+	}
+	if b.ServerSwitchingRules, err = ParseServerSwitchingRules(name, p); err != nil {
+		return nil, err
+	}
+	if b.StickRules, err = ParseStickRules(name, p); err != nil {
+		return nil, err
+	}
+	if b.Filters, err = ParseFilters("backend", name, p); err != nil {
+		return nil, err
+	}
+	if b.LogTargets, err = ParseLogTargets("backend", name, p); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// SerializeStructuredConfiguration writes data to the parser, frontends, backends,
+// resolvers and peers sections not in data are removed. Sections that already exist
+// are changed in place, the lines the structured configuration has no model for,
+// like captures or email alerts, are kept.
+func SerializeStructuredConfiguration(p *parser.Parser, data *models.Configuration) error {
+	if data.Global != nil {
+		if err := SerializeGlobalSection(p, data.Global); err != nil {
+			return err
+		}
+	}
+	if data.Defaults != nil {
+		if err := CreateEditSection(data.Defaults, parser.Defaults, parser.DefaultSectionName, p); err != nil {
+			return err
+		}
+	}
+
+	wanted := map[parser.Section]map[string]bool{
+		parser.Frontends: {},
+		parser.Backends:  {},
+		parser.Resolvers: {},
+		parser.Peers:     {},
+	}
+	want := func(section parser.Section, name string) error {
+		if wanted[section][name] {
+			return NewConfError(ErrValidationError, fmt.Sprintf("%s %s given more than once", section, name))
+		}
+		wanted[section][name] = true
+		return nil
+	}
+	for _, f := range data.Frontends {
+		if f != nil && f.Frontend != nil {
+			if err := want(parser.Frontends, f.Frontend.Name); err != nil {
+				return err
+			}
+		}
+	}
+	for _, b := range data.Backends {
+		if b != nil && b.Backend != nil {
+			if err := want(parser.Backends, b.Backend.Name); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range data.Resolvers {
+		if r != nil && r.Resolver != nil {
+			if err := want(parser.Resolvers, r.Resolver.Name); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ps := range data.PeerSections {
+		if ps != nil && ps.PeerSection != nil {
+			if err := want(parser.Peers, ps.PeerSection.Name); err != nil {
+				return err
+			}
+		}
+	}
+	for section, names := range wanted {
+		existing, err := p.SectionsGet(section)
+		if err != nil {
+			return err
+		}
+		for _, name := range existing {
+			if names[name] {
+				continue
+			}
+			if err := p.SectionsDelete(section, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, f := range data.Frontends {
+		if f == nil {
+			continue
+		}
+		if err := serializeStructuredFrontend(p, f); err != nil {
+			return err
+		}
+	}
+	for _, b := range data.Backends {
+		if b == nil {
+			continue
+		}
+		if err := serializeStructuredBackend(p, b); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.Resolvers {
+		if r == nil {
+			continue
+		}
+		if r.Resolver == nil {
+			return NewConfError(ErrValidationError, "resolvers entry without resolver")
+		}
+		if err := resetStructuredSection(p, parser.Resolvers, r.Resolver.Name, "nameserver"); err != nil {
+			return err
+		}
+		if err := SerializeResolverSection(p, r.Resolver); err != nil {
+			return err
+		}
+		for _, ns := range r.Nameservers {
+			if err := p.Insert(parser.Resolvers, r.Resolver.Name, "nameserver", SerializeNameserver(*ns), -1); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ps := range data.PeerSections {
+		if ps == nil {
+			continue
+		}
+		if ps.PeerSection == nil {
+			return NewConfError(ErrValidationError, "peer_sections entry without peer_section")
+		}
+		if err := resetStructuredSection(p, parser.Peers, ps.PeerSection.Name, "peer"); err != nil {
+			return err
+		}
+		for _, pe := range ps.PeerEntries {
+			if err := p.Insert(parser.Peers, ps.PeerSection.Name, "peer", SerializePeerEntry(*pe), -1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func serializeStructuredFrontend(p *parser.Parser, data *models.ConfigurationFrontend) error { //nolint:gocognit,gocyclo
+	if data.Frontend == nil {
+		return NewConfError(ErrValidationError, "frontends entry without frontend")
+	}
+	name := data.Frontend.Name
+	err := resetStructuredSection(p, parser.Frontends, name,
+		"bind", "acl", "http-request", "http-response", "tcp-request", "use_backend", "filter", "log")
+	if err != nil {
+		return err
+	}
+	if err := CreateEditSection(data.Frontend, parser.Frontends, name, p); err != nil {
+		return err
+	}
+	add := func(attribute string, d common.ParserData) error {
+		return p.Insert(parser.Frontends, name, attribute, d, -1)
+	}
+
+	for _, b := range data.Binds {
+		if err := add("bind", SerializeBind(*b)); err != nil {
+			return err
+		}
+	}
+	if err := SerializeCaptures(name, data.Captures, p); err != nil {
+		return err
+	}
+	for _, a := range data.Acls {
+		if err := add("acl", SerializeACL(*a)); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.HTTPRequestRules {
+		s, err := SerializeHTTPRequestRule(*r)
+		if err != nil {
+			return err
+		}
+		if err := add("http-request", s); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.HTTPResponseRules {
+		if err := add("http-response", SerializeHTTPResponseRule(*r)); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.TCPRequestRules {
+		s, err := SerializeTCPRequestRule(*r)
+		if err != nil {
+			return err
+		}
+		if err := add("tcp-request", s); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.BackendSwitchingRules {
+		if err := add("use_backend", SerializeBackendSwitchingRule(*r)); err != nil {
+			return err
+		}
+	}
+	for _, f := range data.Filters {
+		if err := add("filter", SerializeFilter(*f)); err != nil {
+			return err
+		}
+	}
+	for _, l := range data.LogTargets {
+		if err := add("log", SerializeLogTarget(*l)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func serializeStructuredBackend(p *parser.Parser, data *models.ConfigurationBackend) error { //nolint:gocognit,gocyclo
+	if data.Backend == nil {
+		return NewConfError(ErrValidationError, "backends entry without backend")
+	}
+	name := data.Backend.Name
+	err := resetStructuredSection(p, parser.Backends, name,
+		"server", "acl", "http-request", "http-response", "tcp-request", "tcp-response", "use-server", "stick", "filter", "log")
+	if err != nil {
+		return err
+	}
+	if err := CreateEditSection(data.Backend, parser.Backends, name, p); err != nil {
+		return err
+	}
+	add := func(attribute string, d common.ParserData) error {
+		return p.Insert(parser.Backends, name, attribute, d, -1)
+	}
+
+	for _, s := range data.Servers {
+		if err := add("server", SerializeServer(*s)); err != nil {
+			return err
+		}
+	}
+	for _, a := range data.Acls {
+		if err := add("acl", SerializeACL(*a)); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.HTTPRequestRules {
+		s, err := SerializeHTTPRequestRule(*r)
+		if err != nil {
+			return err
+		}
+		if err := add("http-request", s); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.HTTPResponseRules {
+		if err := add("http-response", SerializeHTTPResponseRule(*r)); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.TCPRequestRules {
+		s, err := SerializeTCPRequestRule(*r)
+		if err != nil {
+			return err
+		}
+		if err := add("tcp-request", s); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.TCPResponseRules {
+		if err := add("tcp-response", SerializeTCPResponseRule(*r)); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.ServerSwitchingRules {
+		if err := add("use-server", SerializeServerSwitchingRule(*r)); err != nil {
+			return err
+		}
+	}
+	for _, r := range data.StickRules {
+		if err := add("stick", SerializeStickRule(*r)); err != nil {
+			return err
+		}
+	}
+	for _, f := range data.Filters {
+		if err := add("filter", SerializeFilter(*f)); err != nil {
+			return err
+		}
+	}
+	for _, l := range data.LogTargets {
+		if err := add("log", SerializeLogTarget(*l)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetStructuredSection creates the section if it does not exist, or removes the
+// lines of the given attributes, the objects of the structured section, from it.
+func resetStructuredSection(p *parser.Parser, section parser.Section, name string, attributes ...string) error {
+	names, err := p.SectionsGet(section)
+	if err != nil {
+		return err
+	}
+	if !misc.StringInSlice(name, names) {
+		return p.SectionsCreate(section, name)
+	}
+	for _, attribute := range attributes {
+		if err := p.Set(section, name, attribute, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStructuredConfiguration runs the semantic checks of the single
+// object methods on the objects of data.
+func validateStructuredConfiguration(data *models.Configuration) error {
+	for _, f := range data.Frontends {
+		if f == nil {
+			continue
+		}
+		for _, b := range f.Binds {
+			if err := validateBind(b); err != nil {
+				return err
+			}
+		}
+		for _, filter := range f.Filters {
+			if err := validateFilter(filter); err != nil {
+				return err
+			}
+		}
+	}
+	for _, b := range data.Backends {
+		if b == nil {
+			continue
+		}
+		for _, s := range b.Servers {
+			if err := validateServer(s); err != nil {
+				return err
+			}
+		}
+		for _, filter := range b.Filters {
+			if err := validateFilter(filter); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
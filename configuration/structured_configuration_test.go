@@ -0,0 +1,363 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestGetStructuredConfiguration(t *testing.T) { //nolint:gocognit,gocyclo
+	v, conf, err := client.GetStructuredConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	// every section must match what the per section getters return
+	_, g, err := client.GetGlobalConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(conf.Global, g) {
+		t.Error("Global section differs from GetGlobalConfiguration")
+	}
+	_, d, err := client.GetDefaultsConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(conf.Defaults, d) {
+		t.Error("Defaults section differs from GetDefaultsConfiguration")
+	}
+
+	_, frontends, err := client.GetFrontends("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(conf.Frontends) != len(frontends) {
+		t.Fatalf("%v frontends returned, expected %v", len(conf.Frontends), len(frontends))
+	}
+	for i, f := range conf.Frontends {
+		if i > 0 && conf.Frontends[i-1].Frontend.Name > f.Frontend.Name {
+			t.Errorf("Frontend %s is not sorted by name", f.Frontend.Name)
+		}
+		_, frontend, _ := client.GetFrontend(f.Frontend.Name, "")
+		if !reflect.DeepEqual(f.Frontend, frontend) {
+			t.Errorf("Frontend %s differs from GetFrontend", f.Frontend.Name)
+		}
+		_, binds, _ := client.GetBinds(f.Frontend.Name, "")
+		if !reflect.DeepEqual(f.Binds, binds) {
+			t.Errorf("Binds of frontend %s differ from GetBinds", f.Frontend.Name)
+		}
+		_, httpRules, _ := client.GetHTTPRequestRules("frontend", f.Frontend.Name, "")
+		if !reflect.DeepEqual(f.HTTPRequestRules, httpRules) {
+			t.Errorf("HTTP request rules of frontend %s differ from GetHTTPRequestRules", f.Frontend.Name)
+		}
+		_, switchingRules, _ := client.GetBackendSwitchingRules(f.Frontend.Name, "")
+		if !reflect.DeepEqual(f.BackendSwitchingRules, switchingRules) {
+			t.Errorf("Backend switching rules of frontend %s differ from GetBackendSwitchingRules", f.Frontend.Name)
+		}
+	}
+
+	_, backends, err := client.GetBackends("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(conf.Backends) != len(backends) {
+		t.Fatalf("%v backends returned, expected %v", len(conf.Backends), len(backends))
+	}
+	for _, b := range conf.Backends {
+		_, backend, _ := client.GetBackend(b.Backend.Name, "")
+		if !reflect.DeepEqual(b.Backend, backend) {
+			t.Errorf("Backend %s differs from GetBackend", b.Backend.Name)
+		}
+		_, servers, _ := client.GetServers(b.Backend.Name, "")
+		if !reflect.DeepEqual(b.Servers, servers) {
+			t.Errorf("Servers of backend %s differ from GetServers", b.Backend.Name)
+		}
+		_, stickRules, _ := client.GetStickRules(b.Backend.Name, "")
+		if !reflect.DeepEqual(b.StickRules, stickRules) {
+			t.Errorf("Stick rules of backend %s differ from GetStickRules", b.Backend.Name)
+		}
+	}
+
+	_, resolvers, err := client.GetResolvers("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(conf.Resolvers) != len(resolvers) {
+		t.Fatalf("%v resolvers returned, expected %v", len(conf.Resolvers), len(resolvers))
+	}
+	for _, r := range conf.Resolvers {
+		_, resolver, _ := client.GetResolver(r.Resolver.Name, "")
+		if !reflect.DeepEqual(r.Resolver, resolver) {
+			t.Errorf("Resolver %s differs from GetResolver", r.Resolver.Name)
+		}
+		_, nameservers, _ := client.GetNameservers(r.Resolver.Name, "")
+		if !reflect.DeepEqual(r.Nameservers, nameservers) {
+			t.Errorf("Nameservers of resolver %s differ from GetNameservers", r.Resolver.Name)
+		}
+	}
+
+	_, peerSections, err := client.GetPeerSections("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(conf.PeerSections) != len(peerSections) {
+		t.Fatalf("%v peer sections returned, expected %v", len(conf.PeerSections), len(peerSections))
+	}
+
+	// the snapshot survives a serialization round trip unchanged
+	b, err := conf.MarshalBinary()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	restored := &models.Configuration{}
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatal(err.Error())
+	}
+	rb, err := restored.MarshalBinary()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(rb) != string(b) {
+		t.Error("Structured configuration changed after a serialization round trip")
+	}
+}
+
+func TestGetStructuredConfigurationMissingTransaction(t *testing.T) {
+	if _, _, err := client.GetStructuredConfiguration("missing_transaction"); err == nil {
+		t.Error("Should throw error, non existant transaction")
+	}
+}
+
+func prepareStructuredClient(t *testing.T, path string) *Client {
+	t.Helper()
+	if err := prepareTestFile(testConf, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return c
+}
+
+// withoutVersion drops the version comment so configurations of different versions compare
+func withoutVersion(conf string) string {
+	lines := []string{}
+	for _, l := range strings.Split(conf, "\n") {
+		if !strings.HasPrefix(l, "# _version") {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestPushStructuredConfiguration(t *testing.T) {
+	path := "/tmp/haproxy-structured-push.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, conf, err := c.GetStructuredConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.PushStructuredConfiguration(conf, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	v++
+
+	_, pushed, err := c.GetStructuredConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected, _ := conf.MarshalBinary()
+	got, _ := pushed.MarshalBinary()
+	if string(got) != string(expected) {
+		t.Errorf("Pushed configuration differs from the one read:\n%s\n%s", got, expected)
+	}
+
+	first, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.PushStructuredConfiguration(pushed, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	second, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if withoutVersion(string(first)) != withoutVersion(string(second)) {
+		t.Errorf("Pushing the same configuration twice changed the file:\n%s\n%s", first, second)
+	}
+
+	// sections missing from the structure are removed
+	pushed.Backends = pushed.Backends[:1]
+	v, _ = c.GetVersion("")
+	if err := c.PushStructuredConfiguration(pushed, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, backends, err := c.GetBackends("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(backends) != 1 || backends[0].Name != pushed.Backends[0].Backend.Name {
+		t.Errorf("%v backends left, expected only %s", len(backends), pushed.Backends[0].Backend.Name)
+	}
+}
+
+const unmodeledConf = `# _version=1
+global
+  daemon
+
+defaults
+  mode http
+
+cache static
+  total-max-size 4
+
+http-errors myerrors
+  errorfile 503 /etc/haproxy/errors/503.http
+
+mailers mta
+  mailer smtp1 10.0.0.9:587
+
+frontend public
+  mode http
+  bind :80 name http
+  capture request header Host len 64
+  declare capture response len 32
+  errorfiles myerrors 503
+  errorfile 403 /etc/haproxy/errors/403.http
+  stick-table type ip size 100k expire 30s store http_req_rate(10s)
+  http-request track-sc0 src
+  http-response cache-store static
+  default_backend app
+
+backend app
+  mode http
+  email-alert mailers mta
+  email-alert to ops@example.com
+  http-request cache-use static
+  http-response cache-store static
+  errorfiles myerrors
+  server app1 10.0.0.1:80 check
+`
+
+func TestPushStructuredConfigurationUnmodeledLines(t *testing.T) {
+	path := "/tmp/haproxy-structured-unmodeled.cfg"
+	if err := prepareTestFile(unmodeledConf, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c, err := prepareClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.CanonicalizeConfiguration("", 1); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, before, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// pushing the configuration read is a no-op, lines without a model are kept
+	v, conf, err := c.GetStructuredConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.PushStructuredConfiguration(conf, "", v); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, after, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if after != before {
+		t.Errorf("Pushing the configuration read changed it:\n%s\n%s", before, after)
+	}
+	if _, captures, err := c.GetCaptures("public", ""); err != nil || len(captures) != 2 {
+		t.Errorf("%v captures left, expected 2: %v", len(captures), err)
+	}
+
+	// objects of the model are still replaced
+	conf.Frontends[0].Binds = nil
+	if err := c.PushStructuredConfiguration(conf, "", v+1); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, binds, err := c.GetBinds("public", ""); err != nil || len(binds) != 0 {
+		t.Errorf("%v binds left, expected 0: %v", len(binds), err)
+	}
+}
+
+func TestPushStructuredConfigurationRollback(t *testing.T) {
+	path := "/tmp/haproxy-structured-rollback.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, conf, err := c.GetStructuredConfiguration("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// a frontend can not be created twice, the push fails after the sections were cleared
+	conf.Frontends = append(conf.Frontends, conf.Frontends[0])
+
+	before, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.PushStructuredConfiguration(conf, "", v); err == nil {
+		t.Fatal("Should throw error, duplicate frontend")
+	}
+	after, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(before) != string(after) {
+		t.Error("Failed push changed the configuration file")
+	}
+	if n := len(c.GetParserTransactions()); n != 0 {
+		t.Errorf("%v transactions left, expected 0", n)
+	}
+
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	p, err := c.GetParser(tr.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	before = []byte(p.String())
+	if err := c.PushStructuredConfiguration(conf, tr.ID, 0); err == nil {
+		t.Fatal("Should throw error, duplicate frontend")
+	}
+	if p.String() != string(before) {
+		t.Error("Failed push changed the transaction")
+	}
+	if err := c.DeleteTransaction(tr.ID); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -17,6 +17,7 @@ package configuration
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -112,13 +113,21 @@ func (c *Client) DeleteTCPRequestRule(id int64, parentType string, parentName st
 	return nil
 }
 
-// CreateTCPRequestRule creates a tcp request rule in configuration. One of version or transactionID is
-// mandatory. Returns error on fail, nil on success.
+// CreateTCPRequestRule creates a tcp request rule in configuration at data.Index, rules at
+// that position and after it are moved down, a nil index appends the rule. One of version
+// or transactionID is mandatory. Returns error on fail, nil on success.
 func (c *Client) CreateTCPRequestRule(parentType string, parentName string, data *models.TCPRequestRule, transactionID string, version int64) error {
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		// type and action combinations are checked by serialization
+		if _, err := SerializeTCPRequestRule(*data); err != nil {
+			return err
 		}
 	}
 
@@ -134,13 +143,18 @@ func (c *Client) CreateTCPRequestRule(parentType string, parentName string, data
 		section = parser.Frontends
 	}
 
+	index := int64(-1)
+	if data.Index != nil {
+		index = *data.Index
+	}
+
 	s, err := SerializeTCPRequestRule(*data)
 	if err != nil {
-		return err
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
-	if err := p.Insert(section, parentName, "tcp-request", s, int(*data.Index)); err != nil {
-		return c.HandleError(strconv.FormatInt(*data.Index, 10), parentType, parentName, t, transactionID == "", err)
+	if err := p.Insert(section, parentName, "tcp-request", s, int(index)); err != nil {
+		return c.HandleError(strconv.FormatInt(index, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := c.SaveData(p, t, transactionID == ""); err != nil {
@@ -156,7 +170,14 @@ func (c *Client) EditTCPRequestRule(id int64, parentType string, parentName stri
 	if c.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return NewConfError(ErrValidationError, validationErr.Error())
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+		// type and action combinations are checked by serialization
+		if _, err := SerializeTCPRequestRule(*data); err != nil {
+			return err
 		}
 	}
 	p, t, err := c.loadDataForChange(transactionID, version)
@@ -177,7 +198,7 @@ func (c *Client) EditTCPRequestRule(id int64, parentType string, parentName stri
 
 	s, err := SerializeTCPRequestRule(*data)
 	if err != nil {
-		return err
+		return c.HandleError(strconv.FormatInt(id, 10), parentType, parentName, t, transactionID == "", err)
 	}
 
 	if err := p.Set(section, parentName, "tcp-request", s, int(id)); err != nil {
@@ -524,7 +545,7 @@ func SerializeTCPRequestRule(f models.TCPRequestRule) (rule types.TCPType, err e
 				CondTest: f.CondTest,
 			}, nil
 		}
-		return nil, NewConfError(ErrValidationError, "unsupported action in tcp_request_rule")
+		return nil, NewConfError(ErrValidationError, fmt.Sprintf("unsupported action %s in %s tcp_request_rule", f.Action, f.Type))
 	case models.TCPRequestRuleTypeContent:
 		switch f.Action {
 		case models.TCPRequestRuleActionAccept:
@@ -686,7 +707,7 @@ func SerializeTCPRequestRule(f models.TCPRequestRule) (rule types.TCPType, err e
 				CondTest: f.CondTest,
 			}, nil
 		}
-		return nil, NewConfError(ErrValidationError, "unsupported action in tcp_request_rule")
+		return nil, NewConfError(ErrValidationError, fmt.Sprintf("unsupported action %s in %s tcp_request_rule", f.Action, f.Type))
 	case models.TCPRequestRuleTypeSession:
 		switch f.Action {
 		case models.TCPRequestRuleActionAccept:
@@ -744,7 +765,7 @@ func SerializeTCPRequestRule(f models.TCPRequestRule) (rule types.TCPType, err e
 				Cond:     f.Cond,
 				CondTest: f.CondTest,
 			}, nil
-		case "sc-inc-gpt0":
+		case models.TCPRequestRuleActionScSetGpt0:
 			return &tcp_types.Session{
 				Action: &tcp_actions.ScSetGpt0{
 					ScID:  f.ScIncID,
@@ -779,15 +800,15 @@ func SerializeTCPRequestRule(f models.TCPRequestRule) (rule types.TCPType, err e
 				CondTest: f.CondTest,
 			}, nil
 		}
-		return nil, NewConfError(ErrValidationError, "unsupported action in tcp_request_rule")
+		return nil, NewConfError(ErrValidationError, fmt.Sprintf("unsupported action %s in %s tcp_request_rule", f.Action, f.Type))
 	case models.TCPRequestRuleTypeInspectDelay:
 		if f.Timeout == nil {
-			return nil, NewConfError(ErrValidationError, "unsupported action in tcp_request_rule")
+			return nil, NewConfError(ErrValidationError, "timeout is required in inspect-delay tcp_request_rule")
 		}
 		return &tcp_types.InspectDelay{
 			Timeout: strconv.FormatInt(*f.Timeout, 10),
 		}, nil
 	}
 
-	return nil, NewConfError(ErrValidationError, "unsupported action in tcp_request_rule")
+	return nil, NewConfError(ErrValidationError, fmt.Sprintf("unsupported type %s in tcp_request_rule", f.Type))
 }
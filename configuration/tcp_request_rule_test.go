@@ -263,3 +263,77 @@ func TestCreateEditDeleteTCPRequestRule(t *testing.T) {
 		version++
 	}
 }
+
+func TestCreateTCPRequestRuleInvalidCombination(t *testing.T) {
+	index := int64(0)
+	tests := []struct {
+		name string
+		rule models.TCPRequestRule
+	}{
+		{
+			name: "do-resolve on connection",
+			rule: models.TCPRequestRule{Index: &index, Type: "connection", Action: "do-resolve", ResolveVar: "txn.ip", ResolveResolvers: "dns", Expr: "hdr(host)"},
+		},
+		{
+			name: "silent-drop on connection",
+			rule: models.TCPRequestRule{Index: &index, Type: "connection", Action: "silent-drop"},
+		},
+		{
+			name: "inspect-delay without timeout",
+			rule: models.TCPRequestRule{Index: &index, Type: "inspect-delay"},
+		},
+	}
+
+	transactions := len(client.GetParserTransactions())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.CreateTCPRequestRule("frontend", "test", &tt.rule, "", version)
+			if err == nil {
+				version++
+				t.Fatal("Should throw validation error")
+			}
+			if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+				t.Errorf("Expected validation error, got: %v", err)
+			}
+		})
+	}
+	if n := len(client.GetParserTransactions()); n != transactions {
+		t.Errorf("%v transactions left, expected %v", n, transactions)
+	}
+	if v, _ := client.GetVersion(""); v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+}
+
+func TestCreateTCPRequestRuleSessionScSetGpt0(t *testing.T) {
+	_, rules, err := client.GetTCPRequestRules("frontend", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	index := int64(len(rules))
+	r := &models.TCPRequestRule{
+		Index:    &index,
+		Type:     "session",
+		Action:   "sc-set-gpt0",
+		ScIncID:  "0",
+		GptValue: "1",
+	}
+	if err := client.CreateTCPRequestRule("frontend", "test", r, "", version); err != nil {
+		t.Fatal(err.Error())
+	}
+	version++
+
+	_, created, err := client.GetTCPRequestRule(index, "frontend", "test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if created.Type != "session" || created.Action != "sc-set-gpt0" || created.ScIncID != "0" || created.GptValue != "1" {
+		t.Errorf("Created rule %s %s %s %s, expected session sc-set-gpt0 0 1", created.Type, created.Action, created.ScIncID, created.GptValue)
+	}
+
+	if err := client.DeleteTCPRequestRule(index, "frontend", "test", "", version); err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+}
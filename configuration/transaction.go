@@ -16,7 +16,7 @@
 package configuration
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -26,7 +26,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	parser "github.com/haproxytech/config-parser/v3"
 	parser_errors "github.com/haproxytech/config-parser/v3/errors"
@@ -58,6 +60,8 @@ type Transaction struct {
 	mu sync.Mutex
 	ClientParams
 	TransactionClient TransactionClient
+	// clock returns the current time when checking transaction age, time.Now if nil
+	clock func() time.Time
 }
 
 // GetTransactions returns an array of transactions
@@ -78,7 +82,11 @@ func (t *Transaction) GetTransaction(transactionID string) (*models.Transaction,
 	}
 	v, _ := t.TransactionClient.GetVersion(transactionID)
 
-	return &models.Transaction{ID: transactionID, Status: models.TransactionStatusInProgress, Version: v}, nil
+	m := &models.Transaction{ID: transactionID, Status: models.TransactionStatusInProgress, Version: v}
+	if t.PersistentTransactions {
+		m.Timestamp = fileTimestamp(t.getTransactionFile(transactionID, ""))
+	}
+	return m, nil
 }
 
 // StartTransaction starts a new empty lbctl transaction
@@ -223,7 +231,11 @@ func (t *Transaction) checkTransactionFile(transactionID string) error {
 	if err != nil {
 		return err
 	}
+	return t.checkConfigurationFile(transactionFile, transactionID)
+}
 
+// checkConfigurationFile runs the HAProxy check or ValidateCmd on the file
+func (t *Transaction) checkConfigurationFile(transactionFile, transactionID string) error {
 	var name string
 	var args []string
 	var envs []string
@@ -242,16 +254,37 @@ func (t *Transaction) checkTransactionFile(transactionID string) error {
 		args = []string{"-f", transactionFile, "-c"}
 	}
 
+	timeout := t.CheckTimeout
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// stderr goes to a file, with a pipe Wait would also wait for any child
+	// process still holding it after the command is killed on timeout
+	stderr, err := ioutil.TempFile("", "haproxy-check")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(stderr.Name())
+	defer stderr.Close()
+
 	// #nosec G204
-	cmd := exec.Command(name, args...)
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Env = envs
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	cmd.Stderr = stderr
 
-	err = cmd.Run()
-	if err != nil {
-		return NewConfError(ErrValidationError, t.parseHAProxyCheckError(stderr.Bytes(), transactionID))
+	if err := cmd.Run(); err != nil {
+		var msg string
+		if ctx.Err() != nil {
+			msg = fmt.Sprintf("err transactionId=%s \nmsg=\"configuration check timed out after %s\"", transactionID, timeout)
+		} else {
+			output, _ := ioutil.ReadFile(stderr.Name())
+			msg = t.parseHAProxyCheckError(output, transactionID)
+		}
+		// the first line only names the transaction, every other one is a problem found
+		return &ConfError{code: ErrValidationError, msg: msg, subErrors: strings.Split(msg, "\n")[1:]}
 	}
 	return nil
 }
@@ -294,10 +327,12 @@ func (t *Transaction) parseHAProxyCheckError(output []byte, id string) string {
 	for _, lineWhole := range strings.Split(oStr, "\n") {
 		line := strings.TrimSpace(lineWhole)
 		if strings.HasPrefix(line, "[ALERT]") {
-			if strings.HasSuffix(line, "fatal errors found in configuration.") {
+			// HAProxy capitalizes these summary lines
+			lower := strings.ToLower(line)
+			if strings.HasSuffix(lower, "fatal errors found in configuration.") {
 				continue
 			}
-			if strings.Contains(line, "error(s) found in configuration file : ") {
+			if strings.Contains(lower, "error(s) found in configuration file : ") {
 				continue
 			}
 
@@ -381,6 +416,48 @@ func (t *Transaction) DeleteTransaction(transactionID string) error {
 	return nil
 }
 
+// CleanupTransactions deletes the transactions whose files were last changed more than
+// TransactionTTL ago and returns their ids. Transactions without a file are kept.
+func (t *Transaction) CleanupTransactions() ([]string, error) {
+	// a transaction being committed or marked outdated must not be removed under it
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	removed := []string{}
+	if t.TransactionTTL <= 0 {
+		return removed, nil
+	}
+
+	transactions, err := t.parseTransactions("")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if t.clock != nil {
+		now = t.clock()
+	}
+	for _, tr := range *transactions {
+		if tr.Timestamp == nil || now.Sub(time.Time(*tr.Timestamp)) <= t.TransactionTTL {
+			continue
+		}
+		dir := tr.Status
+		if tr.Status == models.TransactionStatusInProgress {
+			dir = ""
+		}
+		if err = os.Remove(t.getTransactionFile(tr.ID, dir)); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		if t.TransactionClient.HasParser(tr.ID) {
+			if err = t.TransactionClient.DeleteParser(tr.ID); err != nil {
+				return removed, err
+			}
+		}
+		removed = append(removed, tr.ID)
+	}
+	return removed, nil
+}
+
 func (t *Transaction) parseTransactions(status string) (*models.Transactions, error) { //nolint:gocognit
 	confFileName := filepath.Base(t.ConfigurationFile)
 
@@ -407,7 +484,7 @@ func (t *Transaction) parseTransactions(status string) (*models.Transactions, er
 		}
 		for _, ff := range ffiles {
 			if !ff.IsDir() {
-				if strings.HasPrefix(ff.Name(), confFileName) {
+				if strings.HasPrefix(ff.Name(), confFileName+".") {
 					transactions = append(transactions, t.parseTransactionFile(filepath.Join(t.TransactionDir, f.Name(), ff.Name())))
 				}
 			}
@@ -419,7 +496,7 @@ func (t *Transaction) parseTransactions(status string) (*models.Transactions, er
 		switch {
 		// regular file
 		case !f.IsDir() && t.PersistentTransactions && (status == "" || status == "in_progress"):
-			if strings.HasPrefix(f.Name(), confFileName) {
+			if strings.HasPrefix(f.Name(), confFileName+".") {
 				transactions = append(transactions, t.parseTransactionFile(filepath.Join(t.TransactionDir, f.Name())))
 			}
 		case status == models.TransactionStatusFailed:
@@ -473,13 +550,24 @@ func (t *Transaction) parseTransactionFile(filePath string) *models.Transaction
 	}
 
 	m := &models.Transaction{
-		ID:      tID,
-		Status:  status,
-		Version: v,
+		ID:        tID,
+		Status:    status,
+		Version:   v,
+		Timestamp: fileTimestamp(filePath),
 	}
 	return m
 }
 
+// fileTimestamp returns the last modification time of the file, nil if it can not be read
+func fileTimestamp(filePath string) *strfmt.DateTime {
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return nil
+	}
+	ts := strfmt.DateTime(fi.ModTime())
+	return &ts
+}
+
 func (t *Transaction) createTransactionFiles(transactionID string) error {
 	transDir, err := os.Stat(t.TransactionDir)
 
@@ -633,6 +721,17 @@ func moveFile(src, dest string) error {
 }
 
 func (t *Transaction) SaveData(prsr interface{}, tID string, commitImplicit bool) error {
+	return t.SaveDataCtx(context.Background(), prsr, tID, commitImplicit)
+}
+
+// SaveDataCtx is SaveData that returns ctx.Err() without committing an implicit
+// transaction if ctx is done, the transaction is deleted. The change is already in
+// the parser of an explicit transaction, so it is saved whatever the state of ctx,
+// callers check ctx before changing the parser.
+func (t *Transaction) SaveDataCtx(ctx context.Context, prsr interface{}, tID string, commitImplicit bool) error {
+	if err := ctx.Err(); err != nil && commitImplicit {
+		return t.ErrAndDeleteTransaction(err, tID)
+	}
 	if t.PersistentTransactions {
 		tFile, err := t.GetTransactionFile(tID)
 		if err != nil {
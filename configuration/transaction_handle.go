@@ -0,0 +1,216 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// TransactionHandle gives access to the configuration through an open
+// transaction. Its methods mirror the ones of Client without the transactionID
+// and version arguments. It is only valid inside the WithTransaction callback.
+type TransactionHandle struct {
+	c  *Client
+	id string
+}
+
+// WithTransaction starts a transaction on the current configuration version and
+// calls fn with a handle to it. The transaction is committed if fn returns nil
+// and deleted if fn returns an error or panics, so either all of the changes made
+// through the handle are applied or none of them are. Returns the error of fn or
+// of the commit.
+func (c *Client) WithTransaction(fn func(tx *TransactionHandle) error) error {
+	v, err := c.GetVersion("")
+	if err != nil {
+		return err
+	}
+	t, err := c.StartTransaction(v)
+	if err != nil {
+		return err
+	}
+
+	committed := false
+	defer func() {
+		// a failed commit may already have dropped the transaction
+		if !committed && c.HasParser(t.ID) {
+			_ = c.DeleteTransaction(t.ID)
+		}
+	}()
+
+	if err := fn(&TransactionHandle{c: c, id: t.ID}); err != nil {
+		return err
+	}
+	if _, err := c.CommitTransaction(t.ID); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// ID returns the id of the underlying transaction
+func (t *TransactionHandle) ID() string {
+	return t.id
+}
+
+// GetFrontends returns configuration version and the frontends in the transaction
+func (t *TransactionHandle) GetFrontends() (int64, models.Frontends, error) {
+	return t.c.GetFrontends(t.id)
+}
+
+// GetFrontend returns configuration version and a requested frontend in the transaction
+func (t *TransactionHandle) GetFrontend(name string) (int64, *models.Frontend, error) {
+	return t.c.GetFrontend(name, t.id)
+}
+
+// CreateFrontend creates a frontend in the transaction
+func (t *TransactionHandle) CreateFrontend(data *models.Frontend) error {
+	return t.c.CreateFrontend(data, t.id, 0)
+}
+
+// EditFrontend edits a frontend in the transaction
+func (t *TransactionHandle) EditFrontend(name string, data *models.Frontend) error {
+	return t.c.EditFrontend(name, data, t.id, 0)
+}
+
+// DeleteFrontend deletes a frontend in the transaction
+func (t *TransactionHandle) DeleteFrontend(name string) error {
+	return t.c.DeleteFrontend(name, t.id, 0)
+}
+
+// GetBackends returns configuration version and the backends in the transaction
+func (t *TransactionHandle) GetBackends() (int64, models.Backends, error) {
+	return t.c.GetBackends(t.id)
+}
+
+// GetBackend returns configuration version and a requested backend in the transaction
+func (t *TransactionHandle) GetBackend(name string) (int64, *models.Backend, error) {
+	return t.c.GetBackend(name, t.id)
+}
+
+// CreateBackend creates a backend in the transaction
+func (t *TransactionHandle) CreateBackend(data *models.Backend) error {
+	return t.c.CreateBackend(data, t.id, 0)
+}
+
+// EditBackend edits a backend in the transaction
+func (t *TransactionHandle) EditBackend(name string, data *models.Backend) error {
+	return t.c.EditBackend(name, data, t.id, 0)
+}
+
+// DeleteBackend deletes a backend in the transaction
+func (t *TransactionHandle) DeleteBackend(name string) error {
+	return t.c.DeleteBackend(name, t.id, 0)
+}
+
+// GetBinds returns configuration version and the binds of a frontend in the transaction
+func (t *TransactionHandle) GetBinds(frontend string) (int64, models.Binds, error) {
+	return t.c.GetBinds(frontend, t.id)
+}
+
+// GetBind returns configuration version and a requested bind in the transaction
+func (t *TransactionHandle) GetBind(name string, frontend string) (int64, *models.Bind, error) {
+	return t.c.GetBind(name, frontend, t.id)
+}
+
+// CreateBind creates a bind in the transaction
+func (t *TransactionHandle) CreateBind(frontend string, data *models.Bind) error {
+	return t.c.CreateBind(frontend, data, t.id, 0)
+}
+
+// EditBind edits a bind in the transaction
+func (t *TransactionHandle) EditBind(name string, frontend string, data *models.Bind) error {
+	return t.c.EditBind(name, frontend, data, t.id, 0)
+}
+
+// DeleteBind deletes a bind in the transaction
+func (t *TransactionHandle) DeleteBind(name string, frontend string) error {
+	return t.c.DeleteBind(name, frontend, t.id, 0)
+}
+
+// GetServers returns configuration version and the servers of a backend in the transaction
+func (t *TransactionHandle) GetServers(backend string) (int64, models.Servers, error) {
+	return t.c.GetServers(backend, t.id)
+}
+
+// GetServer returns configuration version and a requested server in the transaction
+func (t *TransactionHandle) GetServer(name string, backend string) (int64, *models.Server, error) {
+	return t.c.GetServer(name, backend, t.id)
+}
+
+// CreateServer creates a server in the transaction
+func (t *TransactionHandle) CreateServer(backend string, data *models.Server) error {
+	return t.c.CreateServer(backend, data, t.id, 0)
+}
+
+// EditServer edits a server in the transaction
+func (t *TransactionHandle) EditServer(name string, backend string, data *models.Server) error {
+	return t.c.EditServer(name, backend, data, t.id, 0)
+}
+
+// DeleteServer deletes a server in the transaction
+func (t *TransactionHandle) DeleteServer(name string, backend string) error {
+	return t.c.DeleteServer(name, backend, t.id, 0)
+}
+
+// GetACLs returns configuration version and the ACL lines of a parent in the transaction
+func (t *TransactionHandle) GetACLs(parentType string, parentName string) (int64, models.Acls, error) {
+	return t.c.GetACLs(parentType, parentName, t.id)
+}
+
+// GetACL returns configuration version and a requested ACL line in the transaction
+func (t *TransactionHandle) GetACL(id int64, parentType string, parentName string) (int64, *models.ACL, error) {
+	return t.c.GetACL(id, parentType, parentName, t.id)
+}
+
+// CreateACL creates an ACL line in the transaction
+func (t *TransactionHandle) CreateACL(parentType string, parentName string, data *models.ACL) error {
+	return t.c.CreateACL(parentType, parentName, data, t.id, 0)
+}
+
+// EditACL edits an ACL line in the transaction
+func (t *TransactionHandle) EditACL(id int64, parentType string, parentName string, data *models.ACL) error {
+	return t.c.EditACL(id, parentType, parentName, data, t.id, 0)
+}
+
+// DeleteACL deletes an ACL line in the transaction
+func (t *TransactionHandle) DeleteACL(id int64, parentType string, parentName string) error {
+	return t.c.DeleteACL(id, parentType, parentName, t.id, 0)
+}
+
+// GetBackendSwitchingRules returns configuration version and the backend switching rules of a frontend in the transaction
+func (t *TransactionHandle) GetBackendSwitchingRules(frontend string) (int64, models.BackendSwitchingRules, error) {
+	return t.c.GetBackendSwitchingRules(frontend, t.id)
+}
+
+// GetBackendSwitchingRule returns configuration version and a requested backend switching rule in the transaction
+func (t *TransactionHandle) GetBackendSwitchingRule(id int64, frontend string) (int64, *models.BackendSwitchingRule, error) {
+	return t.c.GetBackendSwitchingRule(id, frontend, t.id)
+}
+
+// CreateBackendSwitchingRule creates a backend switching rule in the transaction
+func (t *TransactionHandle) CreateBackendSwitchingRule(frontend string, data *models.BackendSwitchingRule) error {
+	return t.c.CreateBackendSwitchingRule(frontend, data, t.id, 0)
+}
+
+// EditBackendSwitchingRule edits a backend switching rule in the transaction
+func (t *TransactionHandle) EditBackendSwitchingRule(id int64, frontend string, data *models.BackendSwitchingRule) error {
+	return t.c.EditBackendSwitchingRule(id, frontend, data, t.id, 0)
+}
+
+// DeleteBackendSwitchingRule deletes a backend switching rule in the transaction
+func (t *TransactionHandle) DeleteBackendSwitchingRule(id int64, frontend string) error {
+	return t.c.DeleteBackendSwitchingRule(id, frontend, t.id, 0)
+}
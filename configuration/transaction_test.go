@@ -0,0 +1,448 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestGetTransactions(t *testing.T) {
+	path := "/tmp/haproxy-transactions.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	started := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		tr, err := c.StartTransaction(v)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		started[tr.ID] = true
+	}
+	defer func() {
+		for id := range started {
+			_ = c.DeleteTransaction(id)
+		}
+	}()
+
+	transactions, err := c.GetTransactions("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(*transactions) != 2 {
+		t.Fatalf("%v transactions returned, expected 2", len(*transactions))
+	}
+	for _, tr := range *transactions {
+		if !started[tr.ID] {
+			t.Errorf("Unexpected transaction %s returned", tr.ID)
+		}
+		if tr.Status != models.TransactionStatusInProgress {
+			t.Errorf("%s: status is %s, expected %s", tr.ID, tr.Status, models.TransactionStatusInProgress)
+		}
+		if tr.Version != v {
+			t.Errorf("%s: version is %v, expected %v", tr.ID, tr.Version, v)
+		}
+		if tr.Timestamp == nil {
+			t.Errorf("%s: timestamp not set", tr.ID)
+		}
+
+		single, err := c.GetTransaction(tr.ID)
+		if err != nil {
+			t.Error(err.Error())
+			continue
+		}
+		if single.ID != tr.ID || single.Status != tr.Status || single.Version != tr.Version || single.Timestamp == nil {
+			t.Errorf("Transaction %v not equal to listed transaction %v", *single, *tr)
+		}
+	}
+
+	failed, err := c.GetTransactions(models.TransactionStatusFailed)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(*failed) != 0 {
+		t.Errorf("%v failed transactions returned, expected 0", len(*failed))
+	}
+
+	for id := range started {
+		if err := c.DeleteTransaction(id); err != nil {
+			t.Error(err.Error())
+		}
+		delete(started, id)
+	}
+	transactions, err = c.GetTransactions("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(*transactions) != 0 {
+		t.Errorf("%v transactions returned after delete, expected 0", len(*transactions))
+	}
+
+	_, err = c.GetTransaction("nonexistent")
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrTransactionDoesNotExist {
+		t.Errorf("Expected transaction does not exist error, got: %v", err)
+	}
+}
+
+func TestCleanupTransactions(t *testing.T) {
+	path := "/tmp/haproxy-transactions-cleanup.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	old, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = c.DeleteTransaction(old.ID) }()
+	recent, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = c.DeleteTransaction(recent.ID) }()
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	c.clock = func() time.Time { return now }
+	for id, mtime := range map[string]time.Time{
+		old.ID:    now.Add(-2 * time.Hour),
+		recent.ID: now.Add(-10 * time.Minute),
+	} {
+		if err := os.Chtimes(c.getTransactionFile(id, ""), mtime, mtime); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// no TTL set, nothing is swept
+	removed, err := c.CleanupTransactions()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(removed) != 0 {
+		t.Errorf("%v transactions removed without a TTL, expected 0", len(removed))
+	}
+
+	c.TransactionTTL = time.Hour
+	removed, err = c.CleanupTransactions()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(removed) != 1 || removed[0] != old.ID {
+		t.Fatalf("Removed %v, expected only %s", removed, old.ID)
+	}
+
+	if _, err := c.GetTransaction(old.ID); err == nil {
+		t.Errorf("Transaction %s still exists after cleanup", old.ID)
+	}
+	if _, err := c.GetTransaction(recent.ID); err != nil {
+		t.Errorf("Transaction %s removed by cleanup: %v", recent.ID, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Configuration file removed by cleanup: %v", err)
+	}
+}
+
+func TestValidateTransaction(t *testing.T) {
+	path := "/tmp/haproxy-transactions-validate.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = c.DeleteTransaction(tr.ID) }()
+
+	b := &models.Backend{Name: "preview", Mode: "http"}
+	if err := c.CreateBackend(b, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	s := &models.Server{Name: "s1", Address: "10.0.0.1", Port: misc.Int64P(80), Maxconn: misc.Int64P(100)}
+	if err := c.CreateServer("preview", s, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := c.ValidateTransaction(tr.ID); err != nil {
+		t.Fatalf("Valid transaction reported as invalid: %v", err)
+	}
+
+	// break the transaction file behind the client's back
+	tFile, err := c.GetTransactionFile(tr.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, err := ioutil.ReadFile(tFile)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	broken := strings.Replace(string(data), "maxconn 100", "maxconn -1", 1)
+	if err := ioutil.WriteFile(tFile, []byte(broken), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err = c.ValidateTransaction(tr.ID)
+	confErr, ok := err.(*ConfError)
+	if !ok || confErr.Code() != ErrValidationError {
+		t.Fatalf("Expected validation error, got: %v", err)
+	}
+	if len(confErr.SubErrors()) != 1 || !strings.Contains(confErr.SubErrors()[0], "maxconn") {
+		t.Errorf("Expected a single maxconn error, got: %v", confErr.SubErrors())
+	}
+
+	// the transaction is left as it was
+	if _, err := c.GetTransaction(tr.ID); err != nil {
+		t.Errorf("Transaction removed by validation: %v", err)
+	}
+	if _, _, err := c.GetBackend("preview", ""); err == nil {
+		t.Error("Backend committed by validation")
+	}
+
+	if err := ioutil.WriteFile(tFile, data, 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	c.ValidateConfigurationFile = true
+	c.ValidateCmd = `sh -c 'echo "[ALERT] 000/000000 (1) : parsing [$DATAPLANEAPI_TRANSACTION_FILE:42] : unknown keyword" >&2; exit 1'`
+	err = c.ValidateTransaction(tr.ID)
+	if confErr, ok := err.(*ConfError); !ok || confErr.Code() != ErrValidationError {
+		t.Fatalf("Expected validation error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), `line=42 msg="unknown keyword"`) {
+		t.Errorf("HAProxy check error not reported: %v", err)
+	}
+
+	if err := c.ValidateTransaction(""); err == nil {
+		t.Error("Should throw error, no transaction given")
+	}
+}
+
+func TestConcurrentTransactionReads(t *testing.T) {
+	path := "/tmp/haproxy-transactions-concurrent.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bindNames := map[string]string{}
+	for _, name := range []string{"concurrent1", "concurrent2"} {
+		tr, err := c.StartTransaction(v)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer func(id string) { _ = c.DeleteTransaction(id) }(tr.ID)
+		b := &models.Bind{Name: name, Address: "127.0.0.1", Port: misc.Int64P(9000)}
+		if err := c.CreateBind("test", b, tr.ID, 0); err != nil {
+			t.Fatal(err.Error())
+		}
+		bindNames[tr.ID] = name
+	}
+
+	// transactions started and deleted meanwhile change the parsers map
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for j := 0; j < 20; j++ {
+			tr, err := c.StartTransaction(v)
+			if err != nil {
+				t.Error(err.Error())
+				return
+			}
+			if err := c.DeleteTransaction(tr.ID); err != nil {
+				t.Error(err.Error())
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		for id, name := range bindNames {
+			wg.Add(1)
+			go func(id, name string) {
+				defer wg.Done()
+				for {
+					_, binds, err := c.GetBinds("test", id)
+					if err != nil {
+						t.Error(err.Error())
+						return
+					}
+					found := false
+					for _, b := range binds {
+						if strings.HasPrefix(b.Name, "concurrent") && b.Name != name {
+							t.Errorf("Transaction %s returned bind %s of another transaction", id, b.Name)
+							return
+						}
+						found = found || b.Name == name
+					}
+					if !found {
+						t.Errorf("Transaction %s did not return bind %s", id, name)
+						return
+					}
+					select {
+					case <-done:
+						return
+					default:
+					}
+				}
+			}(id, name)
+		}
+	}
+	wg.Wait()
+}
+
+func TestCheckTransaction(t *testing.T) {
+	path := "/tmp/haproxy-transactions-check.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	dir, err := ioutil.TempDir("", "haproxy-check-stub")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+	stub := func(name, script string) string {
+		stubPath := dir + "/" + name
+		if err := ioutil.WriteFile(stubPath, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+			t.Fatal(err.Error())
+		}
+		return stubPath
+	}
+	valid := stub("valid", "exit 0")
+	invalid := stub("invalid", `echo "[ALERT] 000/000000 (1) : parsing [$2:12] : unknown keyword 'foo' in 'backend' section" >&2
+echo "[ALERT] 000/000000 (1) : Proxy 'test': unable to find server 'missing'." >&2
+echo "[ALERT] 000/000000 (1) : Error(s) found in configuration file : $2" >&2
+echo "[ALERT] 000/000000 (1) : Fatal errors found in configuration." >&2
+exit 1`)
+	stalled := stub("stalled", "sleep 30")
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = c.DeleteTransaction(tr.ID) }()
+
+	c.Haproxy = valid
+	if err := c.CheckTransaction(tr.ID); err != nil {
+		t.Errorf("Check of a valid transaction failed: %v", err)
+	}
+
+	c.Haproxy = invalid
+	err = c.CheckTransaction(tr.ID)
+	confErr, ok := err.(*ConfError)
+	if !ok || confErr.Code() != ErrValidationError {
+		t.Fatalf("Expected validation error, got: %v", err)
+	}
+	expected := []string{
+		`line=12 msg="unknown keyword 'foo' in 'backend' section"`,
+		`msg="Proxy 'test': unable to find server 'missing'."`,
+	}
+	if !reflect.DeepEqual(confErr.SubErrors(), expected) {
+		t.Errorf("Check errors are %q, expected %q", confErr.SubErrors(), expected)
+	}
+	if _, err := c.GetTransaction(tr.ID); err != nil {
+		t.Errorf("Transaction changed by the check: %v", err)
+	}
+
+	c.Haproxy = stalled
+	c.CheckTimeout = 200 * time.Millisecond
+	start := time.Now()
+	err = c.CheckTransaction(tr.ID)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Check returned after %s, expected a timeout after %s", elapsed, c.CheckTimeout)
+	}
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a timeout error, got: %v", err)
+	}
+}
+
+func TestWithTransaction(t *testing.T) {
+	path := "/tmp/haproxy-with-transaction.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	errMidway := NewConfError(ErrGeneralError, "stop here")
+	var id string
+	err = c.WithTransaction(func(tx *TransactionHandle) error {
+		id = tx.ID()
+		if err := tx.CreateFrontend(&models.Frontend{Name: "atomic_fe", Mode: "http"}); err != nil {
+			return err
+		}
+		if err := tx.CreateBind("atomic_fe", &models.Bind{Name: "atomic_bind", Address: "127.0.0.1", Port: misc.Int64P(8088)}); err != nil {
+			return err
+		}
+		if _, _, err := tx.GetFrontend("atomic_fe"); err != nil {
+			return err
+		}
+		return errMidway
+	})
+	if err != errMidway {
+		t.Fatalf("Expected the callback error, got: %v", err)
+	}
+	if _, _, err := c.GetFrontend("atomic_fe", ""); err == nil {
+		t.Error("Frontend created in a failed transaction was committed")
+	}
+	if nv, _ := c.GetVersion(""); nv != v {
+		t.Errorf("Version %v after a failed transaction, expected %v", nv, v)
+	}
+	if c.HasParser(id) {
+		t.Error("Failed transaction was not deleted")
+	}
+
+	err = c.WithTransaction(func(tx *TransactionHandle) error {
+		if err := tx.CreateBackend(&models.Backend{Name: "atomic_be", Mode: "http"}); err != nil {
+			return err
+		}
+		return tx.CreateServer("atomic_be", &models.Server{Name: "atomic_srv", Address: "10.0.0.8", Port: misc.Int64P(80)})
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, _, err := c.GetServer("atomic_srv", "atomic_be", ""); err != nil {
+		t.Errorf("Server not committed: %v", err)
+	}
+	if nv, _ := c.GetVersion(""); nv != v+1 {
+		t.Errorf("Version %v after a committed transaction, expected %v", nv, v+1)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	strfmt "github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/config-parser/v3"
+	"github.com/haproxytech/config-parser/v3/common"
+	parser_errors "github.com/haproxytech/config-parser/v3/errors"
+	"github.com/haproxytech/config-parser/v3/types"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// GetUsers returns configuration version and an array of
+// configured users in the specified userlist. Returns error on fail.
+func (c *Client) GetUsers(userlist string, transactionID string) (int64, models.Users, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	users, err := ParseUsers(userlist, p)
+	if err != nil {
+		return v, nil, c.HandleError("", "userlist", userlist, "", false, err)
+	}
+
+	return v, users, nil
+}
+
+// GetUser returns configuration version and a requested user
+// in the specified userlist. Returns error on fail or if user does not exist.
+func (c *Client) GetUser(username string, userlist string, transactionID string) (int64, *models.User, error) {
+	p, err := c.GetParser(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	v, err := c.GetVersion(transactionID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		return v, nil, NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+	}
+
+	user, _ := GetUserByName(username, userlist, p)
+	if user == nil {
+		return v, nil, NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("User %s does not exist in userlist %s", username, userlist))
+	}
+
+	return v, user, nil
+}
+
+// DeleteUser deletes a user in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) DeleteUser(username string, userlist string, transactionID string, version int64) error {
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+		return c.HandleError(username, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	user, i := GetUserByName(username, userlist, p)
+	if user == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("User %s does not exist in userlist %s", username, userlist))
+		return c.HandleError(username, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	if err := p.Delete(parser.UserList, userlist, "user", i); err != nil {
+		return c.HandleError(username, "userlist", userlist, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateUser creates a user in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) CreateUser(userlist string, data *models.User, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+		return c.HandleError(data.Username, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	user, _ := GetUserByName(data.Username, userlist, p)
+	if user != nil {
+		e := NewConfError(ErrObjectAlreadyExists, fmt.Sprintf("User %s already exists in userlist %s", data.Username, userlist))
+		return c.HandleError(data.Username, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	if err := p.Insert(parser.UserList, userlist, "user", SerializeUser(*data), -1); err != nil {
+		return c.HandleError(data.Username, "userlist", userlist, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EditUser edits a user in configuration. One of version or transactionID is
+// mandatory. Returns error on fail, nil on success.
+func (c *Client) EditUser(username string, userlist string, data *models.User, transactionID string, version int64) error {
+	if c.UseValidation {
+		validationErr := data.Validate(strfmt.Default)
+		if validationErr != nil {
+			return NewValidationError(validationErr)
+		}
+		if err := c.runValidators(data); err != nil {
+			return err
+		}
+	}
+	p, t, err := c.loadDataForChange(transactionID, version)
+	if err != nil {
+		return err
+	}
+
+	if !c.checkSectionExists(parser.UserList, userlist, p) {
+		e := NewConfError(ErrParentDoesNotExist, fmt.Sprintf("Userlist %s does not exist", userlist))
+		return c.HandleError(data.Username, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	user, i := GetUserByName(username, userlist, p)
+	if user == nil {
+		e := NewConfError(ErrObjectDoesNotExist, fmt.Sprintf("User %s does not exist in userlist %s", username, userlist))
+		return c.HandleError(data.Username, "userlist", userlist, t, transactionID == "", e)
+	}
+
+	if err := p.Set(parser.UserList, userlist, "user", SerializeUser(*data), i); err != nil {
+		return c.HandleError(data.Username, "userlist", userlist, t, transactionID == "", err)
+	}
+
+	if err := c.SaveData(p, t, transactionID == ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ParseUsers(userlist string, p *parser.Parser) (models.Users, error) {
+	users := models.Users{}
+
+	data, err := p.Get(parser.UserList, userlist, "user", false)
+	if err != nil {
+		if errors.Is(err, parser_errors.ErrFetch) {
+			return users, nil
+		}
+		return nil, err
+	}
+
+	for _, u := range data.([]types.User) {
+		users = append(users, ParseUser(u))
+	}
+	return users, nil
+}
+
+func ParseUser(u types.User) *models.User {
+	secure := !u.IsInsecure
+	return &models.User{
+		Username:       u.Name,
+		Password:       u.Password,
+		SecurePassword: &secure,
+		Groups:         strings.Join(u.Groups, ","),
+	}
+}
+
+// SerializeUser converts a user model into its on-disk representation. The
+// password is written as "password" when it is secure (a crypt(3) hash) and as
+// "insecure-password" otherwise.
+func SerializeUser(u models.User) types.User {
+	return types.User{
+		Name:       u.Username,
+		Password:   u.Password,
+		IsInsecure: u.SecurePassword != nil && !*u.SecurePassword,
+		Groups:     common.StringSplitIgnoreEmpty(u.Groups, ','),
+	}
+}
+
+func GetUserByName(username string, userlist string, p *parser.Parser) (*models.User, int) {
+	users, err := ParseUsers(userlist, p)
+	if err != nil {
+		return nil, 0
+	}
+
+	for i, u := range users {
+		if u.Username == username {
+			return u, i
+		}
+	}
+	return nil, 0
+}
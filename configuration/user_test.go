@@ -0,0 +1,166 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestGetUsers(t *testing.T) {
+	v, users, err := client.GetUsers("first", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if len(users) != 2 {
+		t.Errorf("%v users returned, expected 2", len(users))
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	_, _, err = client.GetUsers("doesnotexist", "")
+	if err == nil {
+		t.Error("Should throw error, non existant userlist")
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	v, u, err := client.GetUser("scott", "first", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	if u.Password != "elgato" {
+		t.Errorf("%v: Password not elgato: %v", u.Username, u.Password)
+	}
+	if u.SecurePassword == nil || *u.SecurePassword {
+		t.Errorf("%v: SecurePassword not false: %v", u.Username, u.SecurePassword)
+	}
+	if u.Groups != "G1,G2" {
+		t.Errorf("%v: Groups not G1,G2: %v", u.Username, u.Groups)
+	}
+
+	_, u, err = client.GetUser("tiger", "first", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if u.SecurePassword == nil || !*u.SecurePassword {
+		t.Errorf("%v: SecurePassword not true: %v", u.Username, u.SecurePassword)
+	}
+	if u.Groups != "" {
+		t.Errorf("%v: Groups not empty: %v", u.Username, u.Groups)
+	}
+
+	_, _, err = client.GetUser("doesnotexist", "first", "")
+	if err == nil {
+		t.Error("Should throw error, non existant user")
+	}
+}
+
+func TestCreateEditDeleteUser(t *testing.T) {
+	secure := false
+	u := &models.User{
+		Username:       "lion",
+		Password:       "roar",
+		SecurePassword: &secure,
+		Groups:         "G1,G2",
+	}
+
+	err := client.CreateUser("first", u, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	v, user, err := client.GetUser("lion", "first", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(u, user) {
+		t.Errorf("Created user %v not equal to given user %v", user, u)
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	err = client.CreateUser("first", u, "", version)
+	if err == nil {
+		t.Error("Should throw error user already exists")
+		version++
+	}
+
+	secure = true
+	u = &models.User{
+		Username:       "lion",
+		Password:       "$6$k6y3o.eP$JlKBx9za9667qe4xHSwRv6J.C0/D7cV91",
+		SecurePassword: &secure,
+		Groups:         "G2",
+	}
+
+	err = client.EditUser("lion", "first", u, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	v, user, err = client.GetUser("lion", "first", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(u, user) {
+		t.Errorf("Edited user %v not equal to given user %v", user, u)
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	err = client.DeleteUser("lion", "first", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetUser("lion", "first", "")
+	if err == nil {
+		t.Error("DeleteUser failed, user lion still exists")
+	}
+
+	err = client.DeleteUser("lion", "first", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existant user")
+		version++
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+func TestGetUserlists(t *testing.T) {
+	v, userlists, err := client.GetUserlists("")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if len(userlists) != 1 {
+		t.Errorf("%v userlists returned, expected 1", len(userlists))
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	if userlists[0].Name != "first" {
+		t.Errorf("Expected only first, %v found", userlists[0].Name)
+	}
+}
+
+func TestGetUserlist(t *testing.T) {
+	v, l, err := client.GetUserlist("first", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	if l.Name != "first" {
+		t.Errorf("Expected first userlist, %v found", l.Name)
+	}
+
+	_, _, err = client.GetUserlist("doesnotexist", "")
+	if err == nil {
+		t.Error("Should throw error, non existant userlist")
+	}
+}
+
+func TestCreateDeleteUserlist(t *testing.T) {
+	l := &models.Userlist{Name: "second"}
+	err := client.CreateUserlist(l, "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	v, userlist, err := client.GetUserlist("second", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if userlist == nil || userlist.Name != "second" {
+		t.Errorf("Created userlist %v not found", l.Name)
+	}
+
+	if v != version {
+		t.Errorf("Version %v returned, expected %v", v, version)
+	}
+
+	err = client.CreateUserlist(l, "", version)
+	if err == nil {
+		t.Error("Should throw error userlist already exists")
+		version++
+	}
+
+	err = client.DeleteUserlist("second", "", version)
+	if err != nil {
+		t.Error(err.Error())
+	} else {
+		version++
+	}
+
+	if v, _ = client.GetVersion(""); v != version {
+		t.Error("Version not incremented")
+	}
+
+	_, _, err = client.GetUserlist("second", "")
+	if err == nil {
+		t.Error("DeleteUserlist failed, userlist second still exists")
+	}
+
+	err = client.DeleteUserlist("doesnotexist", "", version)
+	if err == nil {
+		t.Error("Should throw error, non existant userlist")
+		version++
+	}
+}
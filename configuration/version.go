@@ -15,16 +15,43 @@
 
 package configuration
 
-// GetConfigurationVersion returns configuration version
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GetConfigurationVersion returns the version of the committed configuration,
+// or of the transaction if transactionID is set. The version of the committed
+// configuration is read from the version line of the file on disk, so it notices
+// changes made to the file by others, a file without a version line is at
+// version 1. Transactions don't always have a file, their version is taken from
+// their parser. Sections are not parsed, so it is cheap enough for frequent polling.
 func (c *Client) GetConfigurationVersion(transactionID string) (int64, error) {
-	_, err := c.GetParser(transactionID)
+	if transactionID != "" {
+		return c.getVersion(transactionID)
+	}
+	file, err := os.Open(c.ConfigurationFile)
 	if err != nil {
-		return 0, err
+		return 0, NewConfError(ErrCannotReadConfFile, err.Error())
 	}
+	defer file.Close()
 
-	v, err := c.GetVersion(transactionID)
-	if err != nil {
-		return 0, err
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "# _version=") {
+			v, err := strconv.ParseInt(strings.TrimPrefix(line, "# _version="), 10, 64)
+			if err != nil {
+				return 0, NewConfError(ErrCannotReadVersion, err.Error())
+			}
+			return v, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, NewConfError(ErrCannotReadConfFile, err.Error())
 	}
-	return v, nil
+	// the parser starts configurations without a version line at version 1
+	return 1, nil
 }
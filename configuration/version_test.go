@@ -18,6 +18,9 @@ package configuration
 import (
 	"io/ioutil"
 	"testing"
+
+	"github.com/haproxytech/client-native/v2/misc"
+	"github.com/haproxytech/client-native/v2/models"
 )
 
 func generateConfig(config string) (string, error) {
@@ -94,3 +97,101 @@ global
 		})
 	}
 }
+
+func TestClient_GetConfigurationVersionMatchesBinds(t *testing.T) {
+	path := "/tmp/haproxy-version.cfg"
+	c := prepareStructuredClient(t, path)
+	defer func() { _ = deleteTestFile(path) }()
+
+	v, err := c.GetConfigurationVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bv, _, err := c.GetBinds("test", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if v != bv {
+		t.Errorf("GetConfigurationVersion = %v, GetBinds reports %v", v, bv)
+	}
+
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = c.DeleteTransaction(tr.ID) }()
+	if err := c.CreateBind("test", &models.Bind{Name: "version", Address: "127.0.0.1", Port: misc.Int64P(8765)}, tr.ID, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	tv, err := c.GetConfigurationVersion(tr.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bv, _, err = c.GetBinds("test", tr.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if tv != bv {
+		t.Errorf("GetConfigurationVersion(%s) = %v, GetBinds reports %v", tr.ID, tv, bv)
+	}
+
+	// the version is read from disk, so changes made by others are seen
+	_, raw, err := c.GetRawConfiguration("", 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := ioutil.WriteFile(path, []byte("# _version=42\n"+raw), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	if v, err = c.GetConfigurationVersion(""); err != nil {
+		t.Fatal(err.Error())
+	}
+	if v != 42 {
+		t.Errorf("GetConfigurationVersion = %v after the file changed, expected 42", v)
+	}
+
+	if _, err := c.GetConfigurationVersion("doesnotexist"); err == nil {
+		t.Error("Should throw error, transaction does not exist")
+	}
+}
+
+func TestClient_GetConfigurationVersionNonPersistent(t *testing.T) {
+	path := "/tmp/haproxy-version-memory.cfg"
+	if err := prepareTestFile(testConf, path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = deleteTestFile(path) }()
+	c := &Client{}
+	err := c.Init(ClientParams{
+		ConfigurationFile: path,
+		Haproxy:           "echo",
+		UseValidation:     true,
+		TransactionDir:    "/tmp/haproxy-test",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	v, err := c.GetConfigurationVersion("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tr, err := c.StartTransaction(v)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer func() { _ = c.DeleteTransaction(tr.ID) }()
+
+	// the transaction has no file, only a parser
+	tv, err := c.GetConfigurationVersion(tr.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bv, _, err := c.GetBinds("test", tr.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if tv != bv {
+		t.Errorf("GetConfigurationVersion(%s) = %v, GetBinds reports %v", tr.ID, tv, bv)
+	}
+}
@@ -22,6 +22,7 @@ package models
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -67,6 +68,12 @@ type Backend struct {
 	// default server
 	DefaultServer *DefaultServer `json:"default_server,omitempty"`
 
+	// error files
+	ErrorFiles []*Errorfile `json:"error_files"`
+
+	// errorfiles from http errors
+	ErrorFilesFromHTTPErrors []*Errorfiles `json:"errorfiles_from_http_errors"`
+
 	// external check
 	// Enum: [enabled disabled]
 	ExternalCheck string `json:"external_check,omitempty"`
@@ -194,6 +201,14 @@ func (m *Backend) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateErrorFiles(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateErrorFilesFromHTTPErrors(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateExternalCheck(formats); err != nil {
 		res = append(res, err)
 	}
@@ -498,6 +513,56 @@ func (m *Backend) validateDefaultServer(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Backend) validateErrorFiles(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ErrorFiles) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ErrorFiles); i++ {
+		if swag.IsZero(m.ErrorFiles[i]) { // not required
+			continue
+		}
+
+		if m.ErrorFiles[i] != nil {
+			if err := m.ErrorFiles[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("error_files" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *Backend) validateErrorFilesFromHTTPErrors(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ErrorFilesFromHTTPErrors) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ErrorFilesFromHTTPErrors); i++ {
+		if swag.IsZero(m.ErrorFilesFromHTTPErrors[i]) { // not required
+			continue
+		}
+
+		if m.ErrorFilesFromHTTPErrors[i] != nil {
+			if err := m.ErrorFilesFromHTTPErrors[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("errorfiles_from_http_errors" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 var backendTypeExternalCheckPropEnum []interface{}
 
 func init() {
@@ -54,9 +54,11 @@ type Bind struct {
 	Alpn string `json:"alpn,omitempty"`
 
 	// backlog
-	Backlog string `json:"backlog,omitempty"`
+	// Minimum: 0
+	Backlog *int64 `json:"backlog,omitempty"`
 
 	// ca ignore err
+	// Pattern: ^(all|[0-9]+(,[0-9]+)*)$
 	CaIgnoreErr string `json:"ca_ignore_err,omitempty"`
 
 	// ca sign file
@@ -74,10 +76,15 @@ type Bind struct {
 	// ciphersuites
 	Ciphersuites string `json:"ciphersuites,omitempty"`
 
+	// Comment written at the end of the bind line
+	// Pattern: ^[^\r\n]*$
+	Comment string `json:"comment,omitempty"`
+
 	// crl file
 	CrlFile string `json:"crl_file,omitempty"`
 
 	// crt ignore err
+	// Pattern: ^(all|[0-9]+(,[0-9]+)*)$
 	CrtIgnoreErr string `json:"crt_ignore_err,omitempty"`
 
 	// crt list
@@ -120,7 +127,8 @@ type Bind struct {
 	Group string `json:"group,omitempty"`
 
 	// id
-	ID string `json:"id,omitempty"`
+	// Minimum: 0
+	ID *int64 `json:"id,omitempty"`
 
 	// interface
 	Interface string `json:"interface,omitempty"`
@@ -130,13 +138,14 @@ type Bind struct {
 	Level string `json:"level,omitempty"`
 
 	// maxconn
-	Maxconn int64 `json:"maxconn,omitempty"`
+	// Minimum: 0
+	Maxconn *int64 `json:"maxconn,omitempty"`
 
 	// mode
 	Mode string `json:"mode,omitempty"`
 
 	// mss
-	Mss string `json:"mss,omitempty"`
+	Mss *int64 `json:"mss,omitempty"`
 
 	// name
 	// Required: true
@@ -191,6 +200,7 @@ type Bind struct {
 	Process string `json:"process,omitempty"`
 
 	// proto
+	// Enum: [h1 h2]
 	Proto string `json:"proto,omitempty"`
 
 	// severity output
@@ -260,10 +270,34 @@ func (m *Bind) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateBacklog(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCaIgnoreErr(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateComment(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCrtIgnoreErr(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateLevel(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateMaxconn(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateName(formats); err != nil {
 		res = append(res, err)
 	}
@@ -280,6 +314,10 @@ func (m *Bind) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateProto(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSeverityOutput(formats); err != nil {
 		res = append(res, err)
 	}
@@ -336,6 +374,71 @@ func (m *Bind) validateAlpn(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Bind) validateBacklog(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Backlog) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("backlog", "body", int64(*m.Backlog), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Bind) validateCaIgnoreErr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CaIgnoreErr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("ca_ignore_err", "body", string(m.CaIgnoreErr), `^(all|[0-9]+(,[0-9]+)*)$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Bind) validateComment(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Comment) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("comment", "body", string(m.Comment), `^[^\r\n]*$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Bind) validateCrtIgnoreErr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CrtIgnoreErr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("crt_ignore_err", "body", string(m.CrtIgnoreErr), `^(all|[0-9]+(,[0-9]+)*)$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Bind) validateID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ID) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("id", "body", int64(*m.ID), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 var bindTypeLevelPropEnum []interface{}
 
 func init() {
@@ -382,6 +485,19 @@ func (m *Bind) validateLevel(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Bind) validateMaxconn(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Maxconn) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("maxconn", "body", int64(*m.Maxconn), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Bind) validateName(formats strfmt.Registry) error {
 
 	if err := validate.RequiredString("name", "body", string(m.Name)); err != nil {
@@ -442,6 +558,49 @@ func (m *Bind) validateProcess(formats strfmt.Registry) error {
 	return nil
 }
 
+var bindTypeProtoPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["h1","h2"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		bindTypeProtoPropEnum = append(bindTypeProtoPropEnum, v)
+	}
+}
+
+const (
+
+	// BindProtoH1 captures enum value "h1"
+	BindProtoH1 string = "h1"
+
+	// BindProtoH2 captures enum value "h2"
+	BindProtoH2 string = "h2"
+)
+
+// prop value enum
+func (m *Bind) validateProtoEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, bindTypeProtoPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Bind) validateProto(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Proto) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateProtoEnum("proto", "body", m.Proto); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 var bindTypeSeverityOutputPropEnum []interface{}
 
 func init() {
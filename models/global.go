@@ -68,12 +68,15 @@ type Global struct {
 	MasterWorker bool `json:"master-worker,omitempty"`
 
 	// maxconn
+	// Minimum: 1
 	Maxconn int64 `json:"maxconn,omitempty"`
 
 	// nbproc
+	// Minimum: 1
 	Nbproc int64 `json:"nbproc,omitempty"`
 
 	// nbthread
+	// Minimum: 1
 	Nbthread int64 `json:"nbthread,omitempty"`
 
 	// pidfile
@@ -144,6 +147,18 @@ func (m *Global) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMaxconn(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNbproc(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNbthread(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSslModeAsync(formats); err != nil {
 		res = append(res, err)
 	}
@@ -320,6 +335,45 @@ func (m *Global) validateLuaLoads(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Global) validateMaxconn(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Maxconn) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("maxconn", "body", int64(m.Maxconn), 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Global) validateNbproc(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Nbproc) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("nbproc", "body", int64(m.Nbproc), 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Global) validateNbthread(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Nbthread) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("nbthread", "body", int64(m.Nbthread), 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 var globalTypeSslModeAsyncPropEnum []interface{}
 
 func init() {
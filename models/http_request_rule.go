@@ -239,7 +239,7 @@ type HTTPRequestRule struct {
 
 	// type
 	// Required: true
-	// Enum: [allow deny auth redirect tarpit add-header replace-header replace-value del-header set-header set-log-level set-path replace-path set-query set-uri set-var send-spoe-group add-acl del-acl capture track-sc0 track-sc1 track-sc2 set-map del-map cache-use disable-l7-retry early-hint replace-uri sc-inc-gpc0 sc-inc-gpc1 do-resolve set-dst set-dst-port sc-set-gpt0 set-mark set-nice set-method set-priority-class set-priority-offset set-src set-src-por wait-for-handshake set-tos silent-drop unset-var strict-mode lua use-service return]
+	// Enum: [allow deny auth redirect tarpit add-header replace-header replace-value del-header set-header set-log-level set-path replace-path set-query set-uri set-var send-spoe-group add-acl del-acl capture track-sc0 track-sc1 track-sc2 set-map del-map cache-use disable-l7-retry early-hint replace-uri sc-inc-gpc0 sc-inc-gpc1 do-resolve set-dst set-dst-port sc-set-gpt0 set-mark set-nice set-method set-priority-class set-priority-offset set-src set-src-port wait-for-handshake set-tos silent-drop unset-var strict-mode lua use-service return]
 	Type string `json:"type"`
 
 	// uri fmt
@@ -1192,7 +1192,7 @@ var httpRequestRuleTypeTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["allow","deny","auth","redirect","tarpit","add-header","replace-header","replace-value","del-header","set-header","set-log-level","set-path","replace-path","set-query","set-uri","set-var","send-spoe-group","add-acl","del-acl","capture","track-sc0","track-sc1","track-sc2","set-map","del-map","cache-use","disable-l7-retry","early-hint","replace-uri","sc-inc-gpc0","sc-inc-gpc1","do-resolve","set-dst","set-dst-port","sc-set-gpt0","set-mark","set-nice","set-method","set-priority-class","set-priority-offset","set-src","set-src-por","wait-for-handshake","set-tos","silent-drop","unset-var","strict-mode","lua","use-service","return"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["allow","deny","auth","redirect","tarpit","add-header","replace-header","replace-value","del-header","set-header","set-log-level","set-path","replace-path","set-query","set-uri","set-var","send-spoe-group","add-acl","del-acl","capture","track-sc0","track-sc1","track-sc2","set-map","del-map","cache-use","disable-l7-retry","early-hint","replace-uri","sc-inc-gpc0","sc-inc-gpc1","do-resolve","set-dst","set-dst-port","sc-set-gpt0","set-mark","set-nice","set-method","set-priority-class","set-priority-offset","set-src","set-src-port","wait-for-handshake","set-tos","silent-drop","unset-var","strict-mode","lua","use-service","return"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -1325,8 +1325,8 @@ const (
 	// HTTPRequestRuleTypeSetSrc captures enum value "set-src"
 	HTTPRequestRuleTypeSetSrc string = "set-src"
 
-	// HTTPRequestRuleTypeSetSrcPor captures enum value "set-src-por"
-	HTTPRequestRuleTypeSetSrcPor string = "set-src-por"
+	// HTTPRequestRuleTypeSetSrcPort captures enum value "set-src-port"
+	HTTPRequestRuleTypeSetSrcPort string = "set-src-port"
 
 	// HTTPRequestRuleTypeWaitForHandshake captures enum value "wait-for-handshake"
 	HTTPRequestRuleTypeWaitForHandshake string = "wait-for-handshake"
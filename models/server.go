@@ -101,6 +101,10 @@ type Server struct {
 	// ciphersuites
 	Ciphersuites string `json:"ciphersuites,omitempty"`
 
+	// Comment written at the end of the server line
+	// Pattern: ^[^\r\n]*$
+	Comment string `json:"comment,omitempty"`
+
 	// cookie
 	// Pattern: ^[^\s]+$
 	Cookie string `json:"cookie,omitempty"`
@@ -146,6 +150,7 @@ type Server struct {
 	HealthCheckPort *int64 `json:"health_check_port,omitempty"`
 
 	// id
+	// Minimum: 1
 	ID *int64 `json:"id,omitempty"`
 
 	// init addr
@@ -167,12 +172,15 @@ type Server struct {
 	MaxReuse *int64 `json:"max_reuse,omitempty"`
 
 	// maxconn
+	// Minimum: 0
 	Maxconn *int64 `json:"maxconn,omitempty"`
 
 	// maxqueue
+	// Minimum: 0
 	Maxqueue *int64 `json:"maxqueue,omitempty"`
 
 	// minconn
+	// Minimum: 0
 	Minconn *int64 `json:"minconn,omitempty"`
 
 	// name
@@ -286,6 +294,7 @@ type Server struct {
 	SendProxyV2SslCn string `json:"send_proxy_v2_ssl_cn,omitempty"`
 
 	// slowstart
+	// Minimum: 0
 	Slowstart *int64 `json:"slowstart,omitempty"`
 
 	// sni
@@ -339,6 +348,7 @@ type Server struct {
 	TLSTickets string `json:"tls_tickets,omitempty"`
 
 	// track
+	// Pattern: ^[^\s/]+/[^\s/]+$
 	Track string `json:"track,omitempty"`
 
 	// verify
@@ -404,6 +414,10 @@ func (m *Server) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateComment(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCookie(formats); err != nil {
 		res = append(res, err)
 	}
@@ -432,6 +446,10 @@ func (m *Server) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateInitAddr(formats); err != nil {
 		res = append(res, err)
 	}
@@ -444,6 +462,18 @@ func (m *Server) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMaxconn(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMaxqueue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMinconn(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateName(formats); err != nil {
 		res = append(res, err)
 	}
@@ -532,6 +562,10 @@ func (m *Server) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSlowstart(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSni(formats); err != nil {
 		res = append(res, err)
 	}
@@ -576,6 +610,10 @@ func (m *Server) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTrack(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateVerify(formats); err != nil {
 		res = append(res, err)
 	}
@@ -896,6 +934,19 @@ func (m *Server) validateCheckViaSocks4(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Server) validateComment(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Comment) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("comment", "body", string(m.Comment), `^[^\r\n]*$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Server) validateCookie(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Cookie) { // not required
@@ -1141,6 +1192,19 @@ func (m *Server) validateHealthCheckPort(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Server) validateID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ID) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("id", "body", int64(*m.ID), 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Server) validateInitAddr(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.InitAddr) { // not required
@@ -1240,6 +1304,45 @@ func (m *Server) validateMaintenance(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Server) validateMaxconn(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Maxconn) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("maxconn", "body", int64(*m.Maxconn), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Server) validateMaxqueue(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Maxqueue) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("maxqueue", "body", int64(*m.Maxqueue), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Server) validateMinconn(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Minconn) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("minconn", "body", int64(*m.Minconn), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Server) validateName(formats strfmt.Registry) error {
 
 	if err := validate.RequiredString("name", "body", string(m.Name)); err != nil {
@@ -2004,6 +2107,19 @@ func (m *Server) validateSendProxyV2SslCn(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Server) validateSlowstart(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Slowstart) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("slowstart", "body", int64(*m.Slowstart), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Server) validateSni(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Sni) { // not required
@@ -2375,6 +2491,19 @@ func (m *Server) validateTLSTickets(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Server) validateTrack(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Track) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("track", "body", string(m.Track), `^[^\s/]+/[^\s/]+$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 var serverTypeVerifyPropEnum []interface{}
 
 func init() {
@@ -46,6 +46,10 @@ type Transaction struct {
 	// status
 	// Enum: [failed outdated in_progress success]
 	Status string `json:"status,omitempty"`
+
+	// Time of the last change to the transaction file
+	// Format: date-time
+	Timestamp *strfmt.DateTime `json:"timestamp,omitempty"`
 }
 
 // Validate validates this transaction
@@ -60,6 +64,10 @@ func (m *Transaction) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTimestamp(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -128,6 +136,19 @@ func (m *Transaction) validateStatus(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Transaction) validateTimestamp(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Timestamp) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("timestamp", "body", "date-time", m.Timestamp.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Transaction) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -3,6 +3,7 @@ package runtime
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	native_errors "github.com/haproxytech/client-native/v2/errors"
@@ -96,7 +97,7 @@ func (s *SingleRuntime) ShowACLFileEntries(storageName string) (models.ACLFilesE
 	if storageName == "" {
 		return nil, fmt.Errorf("%s %w", "Argument file empty", native_errors.ErrGeneral)
 	}
-	cmd := fmt.Sprintf("show acl %s", storageName)
+	cmd := fmt.Sprintf("show acl %s", aclReference(storageName))
 	response, err := s.ExecuteWithResponse(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", err.Error(), native_errors.ErrNotFound) //nolint:errorlint
@@ -154,10 +155,10 @@ func (s *SingleRuntime) AddACLFileEntry(aclID, value string) error {
 	if m != nil {
 		return fmt.Errorf("%w", native_errors.ErrAlreadyExists)
 	}
-	cmd := fmt.Sprintf("add acl #%s %s", aclID, value)
+	cmd := fmt.Sprintf("add acl %s %s", aclReference(aclID), value)
 	response, err := s.ExecuteWithResponse(cmd)
 	if err != nil {
-		return fmt.Errorf("%s %w", err.Error(), native_errors.ErrGeneral) //nolint:errorlint
+		return aclError(err, native_errors.ErrGeneral)
 	}
 	if strings.Contains(response, "not") && strings.Contains(response, "valid") {
 		return fmt.Errorf("%s %w", strings.TrimSpace(response), native_errors.ErrGeneral)
@@ -167,7 +168,7 @@ func (s *SingleRuntime) AddACLFileEntry(aclID, value string) error {
 
 // GetACLFileEntry returns one Acl runtime setting
 func (s *SingleRuntime) GetACLFileEntry(aclID, value string) (*models.ACLFileEntry, error) {
-	cmd := fmt.Sprintf("get acl #%s %s", aclID, value)
+	cmd := fmt.Sprintf("get acl %s %s", aclReference(aclID), value)
 	response, err := s.ExecuteWithResponse(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", err.Error(), native_errors.ErrNotFound) //nolint:errorlint
@@ -197,7 +198,7 @@ func (s *SingleRuntime) DeleteACLFileEntry(aclID, value string) error {
 	if aclID == "" || value == "" {
 		return fmt.Errorf("%s %w", "One or more Arguments empty", native_errors.ErrGeneral)
 	}
-	cmd := fmt.Sprintf("del acl #%s %s", aclID, value)
+	cmd := fmt.Sprintf("del acl %s %s", aclReference(aclID), value)
 	response, err := s.ExecuteWithResponse(cmd)
 	if err != nil {
 		return fmt.Errorf("%s %w", err.Error(), native_errors.ErrNotFound) //nolint:errorlint
@@ -207,3 +208,37 @@ func (s *SingleRuntime) DeleteACLFileEntry(aclID, value string) error {
 	}
 	return nil
 }
+
+// ClearACL removes all entries from the Acl
+func (s *SingleRuntime) ClearACL(aclID string) error {
+	if aclID == "" {
+		return fmt.Errorf("%s %w", "Argument aclID empty", native_errors.ErrGeneral)
+	}
+	cmd := fmt.Sprintf("clear acl %s", aclReference(aclID))
+	response, err := s.ExecuteWithResponse(cmd)
+	if err != nil {
+		return aclError(err, native_errors.ErrNotFound)
+	}
+	if strings.HasPrefix(strings.TrimSpace(response), "Unknown ACL identifier.") {
+		return fmt.Errorf("%s %w", strings.TrimSpace(response), native_errors.ErrNotFound)
+	}
+	return nil
+}
+
+// aclReference returns the reference of an Acl in runtime commands, a numeric
+// id is prefixed with #, a file name is used as is
+func aclReference(aclID string) string {
+	if _, err := strconv.ParseUint(aclID, 10, 64); err == nil {
+		return "#" + aclID
+	}
+	return aclID
+}
+
+// aclError wraps an error of an Acl command, an unknown Acl is reported as
+// not found, other errors with defaultErr
+func aclError(err error, defaultErr error) error {
+	if strings.Contains(err.Error(), "Unknown ACL identifier") {
+		defaultErr = native_errors.ErrNotFound
+	}
+	return fmt.Errorf("%s %w", err.Error(), defaultErr) //nolint:errorlint
+}
@@ -1,9 +1,11 @@
 package runtime
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
+	native_errors "github.com/haproxytech/client-native/v2/errors"
 	"github.com/haproxytech/client-native/v2/models"
 )
 
@@ -526,3 +528,67 @@ func TestSingleRuntime_DeleteACLFileEntry(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ACLFileEntries(t *testing.T) {
+	haProxy := NewHAProxyMock(t)
+	haProxy.Start()
+	defer haProxy.Stop()
+
+	haProxy.SetResponses(&map[string]string{
+		"show acl /etc/acl/blocklist.txt\n":            "\n0x55c476034560 2.178.160.0/20\n0x55c4760345a0 10.0.0.0/8\n",
+		"show acl #7\n":                                "\n[3]: Unknown ACL identifier. Please use #<id> or <file>.\n",
+		"get acl /etc/acl/blocklist.txt 192.168.0.1\n": "\ntype=ip, case=sensitive, match=no\n",
+		"add acl /etc/acl/blocklist.txt 192.168.0.1\n": "\n",
+		"get acl #0 10.0.0.0/8\n":                      "\ntype=ip, case=sensitive, match=yes, idx=tree, pattern=\"10.0.0.0/8\"\n",
+		"del acl #0 10.0.0.0/8\n":                      "\n",
+		"clear acl /etc/acl/blocklist.txt\n":           "\n",
+		"get acl #7 192.168.0.1\n":                     "\n[3]: Unknown ACL identifier. Please use #<id> or <file>.\n",
+		"add acl #7 192.168.0.1\n":                     "\n[3]: Unknown ACL identifier. Please use #<id> or <file>.\n",
+		"clear acl #7\n":                               "\n[3]: Unknown ACL identifier. Please use #<id> or <file>.\n",
+	})
+
+	c := &Client{}
+	if err := c.InitWithSockets(map[int]string{1: haProxy.Addr().String()}); err != nil {
+		t.Fatalf("Client.InitWithSockets() error = %v", err)
+	}
+
+	entries, err := c.ShowACLFileEntries("/etc/acl/blocklist.txt")
+	if err != nil {
+		t.Fatalf("Client.ShowACLFileEntries() error = %v", err)
+	}
+	want := models.ACLFilesEntries{
+		{ID: "0x55c476034560", Value: "2.178.160.0/20"},
+		{ID: "0x55c4760345a0", Value: "10.0.0.0/8"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Client.ShowACLFileEntries() = %v, want %v", entries, want)
+	}
+
+	if err := c.AddACLFileEntry("/etc/acl/blocklist.txt", "192.168.0.1"); err != nil {
+		t.Errorf("Client.AddACLFileEntry() error = %v", err)
+	}
+	entry, err := c.GetACLFileEntry("0", "10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("Client.GetACLFileEntry() error = %v", err)
+	}
+	if entry.Value != "10.0.0.0/8" {
+		t.Errorf("Client.GetACLFileEntry() = %v, want 10.0.0.0/8", entry.Value)
+	}
+	if err := c.DeleteACLFileEntry("0", "10.0.0.0/8"); err != nil {
+		t.Errorf("Client.DeleteACLFileEntry() error = %v", err)
+	}
+	if err := c.ClearACL("/etc/acl/blocklist.txt"); err != nil {
+		t.Errorf("Client.ClearACL() error = %v", err)
+	}
+
+	// unknown Acl ids are reported as not found
+	if _, err := c.ShowACLFileEntries("7"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("Client.ShowACLFileEntries() error = %v, want not found", err)
+	}
+	if err := c.AddACLFileEntry("7", "192.168.0.1"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("Client.AddACLFileEntry() error = %v, want not found", err)
+	}
+	if err := c.ClearACL("7"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("Client.ClearACL() error = %v, want not found", err)
+	}
+}
@@ -21,9 +21,18 @@ type HAProxyMock struct {
 
 // NewHAProxyMock - create new haproxy mock
 func NewHAProxyMock(t *testing.T) *HAProxyMock {
+	return newHAProxyMock(t, "unix", socket())
+}
+
+// NewHAProxyMockTCP - create new haproxy mock listening on a local TCP port
+func NewHAProxyMockTCP(t *testing.T) *HAProxyMock {
+	return newHAProxyMock(t, "tcp", "127.0.0.1:0")
+}
+
+func newHAProxyMock(t *testing.T, network, address string) *HAProxyMock {
 	haProxyMock := &HAProxyMock{}
 	haProxyMock.t = t
-	l, err := net.Listen("unix", socket())
+	l, err := net.Listen(network, address)
 	if err != nil {
 		t.Fatal(err)
 	}
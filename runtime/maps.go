@@ -155,14 +155,18 @@ func parseMapEntry(line string, hasID bool) *models.MapEntry {
 		return nil
 	}
 
+	// the value runs to the end of the line and may contain spaces
 	m := &models.MapEntry{}
 	if hasID {
+		if len(parts) < 3 {
+			return nil
+		}
 		m.ID = parts[0] // map entries from runtime have ID
 		m.Key = parts[1]
-		m.Value = parts[2]
+		m.Value = strings.Join(parts[2:], " ")
 	} else {
 		m.Key = parts[0] // map entries from file
-		m.Value = parts[1]
+		m.Value = strings.Join(parts[1:], " ")
 	}
 	return m
 }
@@ -189,7 +193,7 @@ func (s *SingleRuntime) AddMapEntry(name, key, value string) error {
 	cmd := fmt.Sprintf("add map %s %s %s", name, key, value)
 	err := s.Execute(cmd)
 	if err != nil {
-		return fmt.Errorf("%s %w", err.Error(), native_errors.ErrGeneral) //nolint:errorlint
+		return mapError(err, native_errors.ErrGeneral)
 	}
 	return nil
 }
@@ -204,7 +208,7 @@ func (s *SingleRuntime) AddMapPayload(name, payload string) error {
 	cmd := fmt.Sprintf("add map %s %s", name, payload)
 	err := s.Execute(cmd)
 	if err != nil {
-		return fmt.Errorf("%s %w", err.Error(), native_errors.ErrGeneral) //nolint:errorlint
+		return mapError(err, native_errors.ErrGeneral)
 	}
 	return nil
 }
@@ -217,16 +221,9 @@ func (s *SingleRuntime) GetMapEntry(name, id string) (*models.MapEntry, error) {
 		return nil, fmt.Errorf("%s %w", err.Error(), native_errors.ErrNotFound) //nolint:errorlint
 	}
 
-	m := &models.MapEntry{}
-	parts := strings.Split(response, ",")
-	for _, p := range parts {
-		kv := strings.Split(p, "=")
-		switch key := strings.TrimSpace(kv[0]); {
-		case key == "key":
-			m.Key = strings.TrimPrefix(strings.TrimSuffix(kv[1], "\""), "\"")
-		case key == "value":
-			m.Value = strings.TrimPrefix(strings.TrimSuffix(kv[1], "\""), "\"")
-		}
+	m := &models.MapEntry{
+		Key:   getMapField(response, "key"),
+		Value: getMapField(response, "value"),
 	}
 	// safe guard m.Key != id:
 	// when id doesn't exists in runtime maps,
@@ -258,3 +255,28 @@ func (s *SingleRuntime) DeleteMapEntry(name, id string) error {
 	}
 	return nil
 }
+
+// getMapField returns a quoted field of the get map output, the value may
+// contain commas and spaces. Sample output:
+// type=str, case=sensitive, found=yes, idx=tree, key="static.example.com", value="be_static", type="str"
+func getMapField(response, field string) string {
+	prefix := field + "=\""
+	start := strings.Index(response, prefix)
+	if start == -1 {
+		return ""
+	}
+	rest := response[start+len(prefix):]
+	if end := strings.Index(rest, "\", "); end != -1 {
+		return rest[:end]
+	}
+	return strings.TrimSuffix(strings.TrimSpace(rest), "\"")
+}
+
+// mapError wraps an error of a map command, an unknown map is reported as
+// not found, other errors with defaultErr
+func mapError(err error, defaultErr error) error {
+	if strings.Contains(err.Error(), "Unknown map identifier") {
+		defaultErr = native_errors.ErrNotFound
+	}
+	return fmt.Errorf("%s %w", err.Error(), defaultErr) //nolint:errorlint
+}
@@ -0,0 +1,121 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package runtime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	native_errors "github.com/haproxytech/client-native/v2/errors"
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+const unknownMap = "\n[3]: Unknown map identifier. Please use #<id> or <file>.\n"
+
+func TestSingleRuntime_MapEntries(t *testing.T) {
+	haProxy := NewHAProxyMock(t)
+	haProxy.Start()
+	defer haProxy.Stop()
+
+	haProxy.SetResponses(&map[string]string{
+		"show map /etc/maps/hosts.map\n": "\n0x55d155c6fbf0 static.example.com be_static\n" +
+			"0x55d155c6fc30 www.example.com be_www be_fallback\n",
+		"get map /etc/maps/hosts.map static.example.com\n": "\ntype=str, case=sensitive, found=yes, idx=tree, " +
+			"key=\"static.example.com\", value=\"be_static\", type=\"str\"\n",
+		"get map /etc/maps/hosts.map api.example.com\n":           "\ntype=str, case=sensitive, found=no\n",
+		"add map /etc/maps/hosts.map api.example.com be_api\n":    "\n",
+		"set map /etc/maps/hosts.map static.example.com be_cdn\n": "\n",
+		"del map /etc/maps/hosts.map static.example.com\n":        "\n",
+		"clear map /etc/maps/hosts.map\n":                         "\n",
+		"show map /etc/maps/missing.map\n":                        unknownMap,
+		"get map /etc/maps/missing.map api.example.com\n":         unknownMap,
+		"add map /etc/maps/missing.map api.example.com be_api\n":  unknownMap,
+		"set map /etc/maps/missing.map api.example.com be_api\n":  unknownMap,
+		"del map /etc/maps/missing.map api.example.com\n":         unknownMap,
+	})
+
+	s := &SingleRuntime{}
+	if err := s.Init(haProxy.Addr().String(), 0, 0); err != nil {
+		t.Fatalf("SingleRuntime.Init() error = %v", err)
+	}
+
+	entries, err := s.ShowMapEntries("/etc/maps/hosts.map")
+	if err != nil {
+		t.Fatalf("SingleRuntime.ShowMapEntries() error = %v", err)
+	}
+	want := models.MapEntries{
+		{ID: "0x55d155c6fbf0", Key: "static.example.com", Value: "be_static"},
+		{ID: "0x55d155c6fc30", Key: "www.example.com", Value: "be_www be_fallback"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("SingleRuntime.ShowMapEntries() = %v, want %v", entries, want)
+	}
+
+	entry, err := s.GetMapEntry("/etc/maps/hosts.map", "static.example.com")
+	if err != nil {
+		t.Fatalf("SingleRuntime.GetMapEntry() error = %v", err)
+	}
+	if entry.Key != "static.example.com" || entry.Value != "be_static" {
+		t.Errorf("SingleRuntime.GetMapEntry() = %v %v, want static.example.com be_static", entry.Key, entry.Value)
+	}
+	if _, err := s.GetMapEntry("/etc/maps/hosts.map", "api.example.com"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("SingleRuntime.GetMapEntry() of a missing key error = %v, want not found", err)
+	}
+
+	if err := s.AddMapEntry("/etc/maps/hosts.map", "api.example.com", "be_api"); err != nil {
+		t.Errorf("SingleRuntime.AddMapEntry() error = %v", err)
+	}
+	if err := s.AddMapEntry("/etc/maps/hosts.map", "static.example.com", "be_static"); !errors.Is(err, native_errors.ErrAlreadyExists) {
+		t.Errorf("SingleRuntime.AddMapEntry() of an existing key error = %v, want already exists", err)
+	}
+	if err := s.SetMapEntry("/etc/maps/hosts.map", "static.example.com", "be_cdn"); err != nil {
+		t.Errorf("SingleRuntime.SetMapEntry() error = %v", err)
+	}
+	if err := s.DeleteMapEntry("/etc/maps/hosts.map", "static.example.com"); err != nil {
+		t.Errorf("SingleRuntime.DeleteMapEntry() error = %v", err)
+	}
+	if err := s.ClearMap("/etc/maps/hosts.map"); err != nil {
+		t.Errorf("SingleRuntime.ClearMap() error = %v", err)
+	}
+
+	// unknown maps are reported as not found by every operation
+	if _, err := s.ShowMapEntries("/etc/maps/missing.map"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("SingleRuntime.ShowMapEntries() error = %v, want not found", err)
+	}
+	if err := s.AddMapEntry("/etc/maps/missing.map", "api.example.com", "be_api"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("SingleRuntime.AddMapEntry() error = %v, want not found", err)
+	}
+	if err := s.SetMapEntry("/etc/maps/missing.map", "api.example.com", "be_api"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("SingleRuntime.SetMapEntry() error = %v, want not found", err)
+	}
+	if err := s.DeleteMapEntry("/etc/maps/missing.map", "api.example.com"); !errors.Is(err, native_errors.ErrNotFound) {
+		t.Errorf("SingleRuntime.DeleteMapEntry() error = %v, want not found", err)
+	}
+}
+
+func TestGetMapField(t *testing.T) {
+	response := "type=str, case=sensitive, found=yes, idx=tree, key=\"/api\", value=\"be_api, be_fallback\", type=\"str\""
+	if got := getMapField(response, "key"); got != "/api" {
+		t.Errorf("getMapField() key = %v, want /api", got)
+	}
+	if got := getMapField(response, "value"); got != "be_api, be_fallback" {
+		t.Errorf("getMapField() value = %v, want be_api, be_fallback", got)
+	}
+	if got := getMapField("type=str, case=sensitive, found=no", "key"); got != "" {
+		t.Errorf("getMapField() key of a missing entry = %v, want empty", got)
+	}
+}
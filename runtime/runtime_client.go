@@ -655,3 +655,137 @@ func (c *Client) ParseMapEntries(output string) models.MapEntries {
 func (c *Client) ParseMapEntriesFromFile(inputFile io.Reader, hasID bool) models.MapEntries {
 	return parseMapEntriesFromFile(inputFile, hasID)
 }
+
+// ShowACLS returns structured unique Acl files
+func (c *Client) ShowACLS() (models.ACLFiles, error) {
+	acls := models.ACLFiles{}
+	var lastErr error
+	for _, runtime := range c.runtimes {
+		m, err := runtime.ShowACLS()
+		if err != nil {
+			lastErr = err
+		}
+
+		if len(acls) == 0 {
+			acls = append(acls, m...)
+		} else {
+			// merge unique Acls from all processes
+			for i := 0; i < len(m); i++ {
+				exists := false
+				for j := 0; j < len(acls); j++ {
+					if m[i].ID == acls[j].ID {
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					acls = append(acls, m[i])
+				}
+			}
+		}
+	}
+	if len(acls) > 0 {
+		return acls, nil
+	}
+	return nil, lastErr
+}
+
+// GetACL returns one structured runtime Acl file
+func (c *Client) GetACL(storageName string) (*models.ACLFile, error) {
+	var lastErr error
+	for _, runtime := range c.runtimes {
+		m, err := runtime.GetACL(storageName)
+		if m != nil {
+			return m, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// ShowACLFileEntries returns Acl entries by Acl id or file name
+func (c *Client) ShowACLFileEntries(aclID string) (models.ACLFilesEntries, error) {
+	entries := models.ACLFilesEntries{}
+	var lastErr error
+	for _, runtime := range c.runtimes {
+		m, err := runtime.ShowACLFileEntries(aclID)
+		if err != nil {
+			lastErr = err
+		}
+
+		if len(entries) == 0 {
+			entries = append(entries, m...)
+		} else {
+			// merge unique Acl entries from all processes
+			for i := 0; i < len(m); i++ {
+				exists := false
+				for j := 0; j < len(entries); j++ {
+					if m[i].Value == entries[j].Value {
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					entries = append(entries, m[i])
+				}
+			}
+		}
+	}
+	if len(entries) > 0 {
+		return entries, nil
+	}
+	return nil, lastErr
+}
+
+// GetACLFileEntry returns one Acl runtime entry
+func (c *Client) GetACLFileEntry(aclID, value string) (*models.ACLFileEntry, error) {
+	var lastErr error
+	for _, runtime := range c.runtimes {
+		m, err := runtime.GetACLFileEntry(aclID, value)
+		if m != nil {
+			return m, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// AddACLFileEntry adds an entry into the Acl
+func (c *Client) AddACLFileEntry(aclID, value string) error {
+	var lastErr error
+	for _, runtime := range c.runtimes {
+		err := runtime.AddACLFileEntry(aclID, value)
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// DeleteACLFileEntry deletes all the Acl entries matching the value
+func (c *Client) DeleteACLFileEntry(aclID, value string) error {
+	var lastErr error
+	for _, runtime := range c.runtimes {
+		err := runtime.DeleteACLFileEntry(aclID, value)
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// ClearACL removes all entries from the Acl
+func (c *Client) ClearACL(aclID string) error {
+	var lastErr error
+	for _, runtime := range c.runtimes {
+		err := runtime.ClearACL(aclID)
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
@@ -59,24 +59,17 @@ func ServerHealthValid(health string) bool {
 	return ok
 }
 
-// ServerWeightValid checks if server state is valid
+// ServerWeightValid checks if server weight is valid, either an absolute weight
+// between 0 and 256 or a percentage of the initial weight. The weight a percentage
+// results in is checked by HAProxy, which reports it when it is too high.
 func ServerWeightValid(weight string) bool {
-	var n int64
-	var err error
 	if strings.HasSuffix(weight, "%") {
-		percent := strings.TrimSuffix(weight, "%")
-		if n, err = strconv.ParseInt(percent, 10, 64); err != nil {
-			return false
-		}
-		if n > -1 && n < 101 {
-			return true
-		}
+		n, err := strconv.ParseInt(strings.TrimSuffix(weight, "%"), 10, 64)
+		return err == nil && n > -1
 	}
-	if n, err = strconv.ParseInt(weight, 10, 64); err != nil {
+	n, err := strconv.ParseInt(weight, 10, 64)
+	if err != nil {
 		return false
 	}
-	if n > -1 && n < 257 {
-		return true
-	}
-	return false
+	return n > -1 && n < 257
 }
@@ -16,12 +16,21 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"time"
 )
 
+const (
+	// DefaultDialTimeout is the time allowed to connect to a runtime API socket
+	DefaultDialTimeout = 5 * time.Second
+	// DefaultCommandTimeout is the time allowed for a runtime API command to complete
+	DefaultCommandTimeout = 30 * time.Second
+)
+
 // TaskResponse ...
 type TaskResponse struct {
 	result string
@@ -40,17 +49,24 @@ type SingleRuntime struct {
 	socketPath string
 	worker     int
 	process    int
+	// dialTimeout and timeout bound connecting to the socket and running a command
+	dialTimeout time.Duration
+	timeout     time.Duration
 }
 
 // Init must be given path to runtime socket and worker number. If in master-worker mode,
 // give the path to the master socket path, and non 0 number for workers. Process is for
 // nbproc > 1. In master-worker mode it's the same as the worker number, but when having
-// multiple stats socket lines bound to processes then use the correct process number
+// multiple stats socket lines bound to processes then use the correct process number.
+// The socket is a unix socket path, or a TCP address given as host:port or with
+// an ipv4@, ipv6@ or tcp@ prefix as in the HAProxy stats socket directive.
 func (s *SingleRuntime) Init(socketPath string, worker int, process int) error {
 	s.socketPath = socketPath
 	s.jobs = make(chan Task)
 	s.worker = worker
 	s.process = process
+	s.dialTimeout = DefaultDialTimeout
+	s.timeout = DefaultCommandTimeout
 	go s.handleIncommingJobs()
 	return nil
 }
@@ -70,7 +86,13 @@ func (s *SingleRuntime) readFromSocket(command string) (string, error) {
 	var api net.Conn
 	var err error
 
-	if api, err = net.Dial("unix", s.socketPath); err != nil {
+	network, address := socketAddress(s.socketPath)
+	if api, err = net.DialTimeout(network, address, s.dialTimeout); err != nil {
+		return "", err
+	}
+	// a stalled HAProxy must not block the job queue forever
+	if err = api.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		_ = api.Close()
 		return "", err
 	}
 	fullCommand := fmt.Sprintf("set severity-output number;%s\n", command)
@@ -79,6 +101,7 @@ func (s *SingleRuntime) readFromSocket(command string) (string, error) {
 	}
 	_, err = api.Write([]byte(fullCommand))
 	if err != nil {
+		_ = api.Close()
 		return "", err
 	}
 	// return "", nil
@@ -90,10 +113,15 @@ func (s *SingleRuntime) readFromSocket(command string) (string, error) {
 	buf := make([]byte, bufferSize)
 	var data strings.Builder
 	for {
-		if n, readErr := api.Read(buf); readErr != nil {
+		n, readErr := api.Read(buf)
+		data.Write(buf[0:n])
+		if readErr != nil {
+			var netErr net.Error
+			if !errors.Is(readErr, io.EOF) && errors.As(readErr, &netErr) && netErr.Timeout() {
+				_ = api.Close()
+				return "", fmt.Errorf("no response from %s within %s", s.socketPath, s.timeout)
+			}
 			break
-		} else {
-			data.Write(buf[0:n])
 		}
 	}
 	_ = api.Close()
@@ -142,7 +170,8 @@ func (s *SingleRuntime) ExecuteWithResponse(command string) (string, error) {
 }
 
 func (s *SingleRuntime) executeRaw(command string, retry int) (string, error) {
-	response := make(chan TaskResponse)
+	// buffered, so a late response does not block the job queue after a timeout
+	response := make(chan TaskResponse, 1)
 	task := Task{
 		command:  command,
 		response: response,
@@ -155,7 +184,33 @@ func (s *SingleRuntime) executeRaw(command string, retry int) (string, error) {
 			return s.executeRaw(command, retry)
 		}
 		return rsp.result, rsp.err
-	case <-time.After(time.Duration(30) * time.Second):
+	case <-time.After(s.dialTimeout + s.timeout):
 		return "", fmt.Errorf("timeout reached")
 	}
 }
+
+// socketAddress returns the network and the address to dial for a runtime API socket
+func socketAddress(socketPath string) (network, address string) {
+	if i := strings.Index(socketPath, "@"); i > 0 {
+		switch socketPath[:i] {
+		case "unix":
+			return "unix", socketPath[i+1:]
+		case "tcp", "ipv4", "ipv6":
+			return "tcp", tcpAddress(socketPath[i+1:])
+		}
+	}
+	if strings.HasPrefix(socketPath, "/") || !strings.Contains(socketPath, ":") {
+		return "unix", socketPath
+	}
+	return "tcp", tcpAddress(socketPath)
+}
+
+// tcpAddress splits an address at its last colon, so IPv6 addresses such as
+// ::1:9999 are dialed as [::1]:9999
+func tcpAddress(address string) string {
+	i := strings.LastIndex(address, ":")
+	if i < 0 || strings.HasPrefix(address, "[") {
+		return address
+	}
+	return net.JoinHostPort(address[:i], address[i+1:])
+}
@@ -0,0 +1,186 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package runtime
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSingleRuntime_SetServerState(t *testing.T) {
+	unixSocket := NewHAProxyMock(t)
+	unixSocket.Start()
+	defer unixSocket.Stop()
+	tcpSocket := NewHAProxyMockTCP(t)
+	tcpSocket.Start()
+	defer tcpSocket.Stop()
+
+	responses := map[string]string{
+		"set server test/webserv state maint\n": "\n",
+		"set server test/missing state ready\n": "\n[3]: No such server.\n",
+	}
+	unixSocket.SetResponses(&responses)
+	tcpSocket.SetResponses(&responses)
+
+	type args struct {
+		backend string
+		server  string
+		state   string
+	}
+	tests := []struct {
+		name       string
+		socketPath string
+		args       args
+		wantErr    string
+	}{
+		{
+			name:       "Server put in maintenance on a unix socket",
+			socketPath: unixSocket.Addr().String(),
+			args:       args{backend: "test", server: "webserv", state: "maint"},
+		},
+		{
+			name:       "Server put in maintenance on a TCP socket",
+			socketPath: tcpSocket.Addr().String(),
+			args:       args{backend: "test", server: "webserv", state: "maint"},
+		},
+		{
+			name:       "Server put in maintenance on an ipv4@ TCP socket",
+			socketPath: "ipv4@" + tcpSocket.Addr().String(),
+			args:       args{backend: "test", server: "webserv", state: "maint"},
+		},
+		{
+			name:       "Unknown server, should return the socket error",
+			socketPath: tcpSocket.Addr().String(),
+			args:       args{backend: "test", server: "missing", state: "ready"},
+			wantErr:    "No such server.",
+		},
+		{
+			name:       "Invalid state, should not reach the socket",
+			socketPath: tcpSocket.Addr().String(),
+			args:       args{backend: "test", server: "webserv", state: "up"},
+			wantErr:    "bad request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SingleRuntime{}
+			if err := s.Init(tt.socketPath, 0, 0); err != nil {
+				t.Fatalf("SingleRuntime.Init() error = %v", err)
+			}
+			err := s.SetServerState(tt.args.backend, tt.args.server, tt.args.state)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("SingleRuntime.SetServerState() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("SingleRuntime.SetServerState() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSingleRuntime_SetServerWeight(t *testing.T) {
+	haProxy := NewHAProxyMock(t)
+	haProxy.Start()
+	defer haProxy.Stop()
+
+	haProxy.SetResponses(&map[string]string{
+		"set server test/webserv weight 50\n":   "\n",
+		"set server test/webserv weight 50%\n":  "\n",
+		"set server test/webserv weight 300%\n": "\n[3]: Relative weight too high.\n",
+	})
+
+	tests := []struct {
+		name    string
+		weight  string
+		wantErr string
+	}{
+		{name: "Absolute weight", weight: "50"},
+		{name: "Relative weight", weight: "50%"},
+		{name: "Relative weight over 256, should return the socket error", weight: "300%", wantErr: "Relative weight too high."},
+		{name: "Absolute weight over 256, should not reach the socket", weight: "257", wantErr: "bad request"},
+		{name: "Not a number, should not reach the socket", weight: "half", wantErr: "bad request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SingleRuntime{}
+			if err := s.Init(haProxy.Addr().String(), 0, 0); err != nil {
+				t.Fatalf("SingleRuntime.Init() error = %v", err)
+			}
+			err := s.SetServerWeight("test", "webserv", tt.weight)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("SingleRuntime.SetServerWeight() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("SingleRuntime.SetServerWeight() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSingleRuntime_Timeout(t *testing.T) {
+	// accepts connections and never answers
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	s := &SingleRuntime{}
+	if err := s.Init(l.Addr().String(), 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	s.timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	err = s.SetServerState("test", "webserv", "ready")
+	if err == nil || !strings.Contains(err.Error(), "no response") {
+		t.Errorf("Expected a timeout error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("SetServerState returned after %s, expected a timeout after %s", elapsed, s.timeout)
+	}
+}
+
+func TestSocketAddress(t *testing.T) {
+	tests := []struct {
+		socketPath string
+		network    string
+		address    string
+	}{
+		{"/var/run/haproxy.sock", "unix", "/var/run/haproxy.sock"},
+		{"unix@/var/run/haproxy.sock", "unix", "/var/run/haproxy.sock"},
+		{"127.0.0.1:9999", "tcp", "127.0.0.1:9999"},
+		{"ipv4@127.0.0.1:9999", "tcp", "127.0.0.1:9999"},
+		{"ipv6@::1:9999", "tcp", "[::1]:9999"},
+	}
+	for _, tt := range tests {
+		network, address := socketAddress(tt.socketPath)
+		if network != tt.network || address != tt.address {
+			t.Errorf("socketAddress(%q) = %s %s, want %s %s", tt.socketPath, network, address, tt.network, tt.address)
+		}
+	}
+}
@@ -38,29 +38,54 @@ func (s *SingleRuntime) GetStats() *models.NativeStatsCollection {
 		result.Error = err.Error()
 		return result
 	}
-	lines := strings.Split(rawdata[2:], "\n")
+	result.Stats = ParseStats(rawdata)
+	return result
+}
+
+// stat types of the type column of show stat
+const (
+	statTypeFrontend = "0"
+	statTypeBackend  = "1"
+	statTypeServer   = "2"
+	statTypeListener = "3"
+)
+
+// ParseStats parses the CSV output of show stat. Columns are read by the
+// names given in the header line, so their order and number may differ between
+// HAProxy versions, and fields missing from a shorter row are left empty.
+// Listener rows (option socket-stats) are skipped.
+func ParseStats(rawdata string) []*models.NativeStat {
 	stats := []*models.NativeStat{}
-	keys := strings.Split(lines[0], ",")
-	for i := 1; i < len(lines); i++ {
-		data := map[string]string{}
-		line := strings.Split(lines[i], ",")
-		if len(line) < len(keys) {
+	lines := strings.Split(strings.TrimSpace(rawdata), "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "#") {
+		return stats
+	}
+	keys := strings.Split(strings.TrimSpace(strings.TrimPrefix(lines[0], "#")), ",")
+	for _, l := range lines[1:] {
+		if strings.TrimSpace(l) == "" {
 			continue
 		}
-		for index, key := range keys {
-			if len(line[index]) > 0 {
-				data[key] = line[index]
+		data := map[string]string{}
+		for index, value := range strings.Split(l, ",") {
+			if index < len(keys) && keys[index] != "" && value != "" {
+				data[keys[index]] = value
 			}
 		}
+
 		oneLineData := &models.NativeStat{}
-		tString := strings.ToLower(line[1])
-		if tString == "backend" || tString == "frontend" {
-			oneLineData.Name = line[0]
-			oneLineData.Type = tString
-		} else {
-			oneLineData.Name = tString
-			oneLineData.Type = "server"
-			oneLineData.BackendName = line[0]
+		switch statType(data) {
+		case statTypeFrontend:
+			oneLineData.Name = data["pxname"]
+			oneLineData.Type = models.NativeStatTypeFrontend
+		case statTypeBackend:
+			oneLineData.Name = data["pxname"]
+			oneLineData.Type = models.NativeStatTypeBackend
+		case statTypeServer:
+			oneLineData.Name = data["svname"]
+			oneLineData.Type = models.NativeStatTypeServer
+			oneLineData.BackendName = data["pxname"]
+		default:
+			continue
 		}
 
 		var st models.NativeStatStats
@@ -81,6 +106,23 @@ func (s *SingleRuntime) GetStats() *models.NativeStatsCollection {
 
 		stats = append(stats, oneLineData)
 	}
-	result.Stats = stats
-	return result
+	return stats
+}
+
+// statType returns the type column of a show stat row, falling back to the
+// svname column for outputs without it
+func statType(data map[string]string) string {
+	if t, ok := data["type"]; ok {
+		return t
+	}
+	switch data["svname"] {
+	case "":
+		return statTypeListener
+	case "FRONTEND":
+		return statTypeFrontend
+	case "BACKEND":
+		return statTypeBackend
+	default:
+		return statTypeServer
+	}
 }
@@ -0,0 +1,117 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v2/models"
+)
+
+// captured from HAProxy 2.2 with the columns after check_duration cut off,
+// the last row is cut short
+const showStat = "" +
+	"# pxname,svname,qcur,qmax,scur,smax,slim,stot,bin,bout,dreq,dresp,ereq,econ,eresp,wretr,wredis,status,weight,act,bck,chkfail,chkdown,lastchg,downtime,qlimit,pid,iid,sid,throttle,lbtot,tracked,type,rate,rate_lim,rate_max,check_status,check_code,check_duration,\n" +
+	"http,FRONTEND,,,2,10,2000,152,36810,951072,0,0,4,,,,,OPEN,,,,,,,,,1,2,0,,,,0,1,0,12,,,,\n" +
+	"http,sock-1,,,0,0,2000,0,0,0,0,0,0,,,,,OPEN,,,,,,,,,1,2,1,,,,3,,,,,,,\n" +
+	"test,WebServ,0,0,1,5,,150,36810,951072,,0,,0,0,0,0,UP,10,1,0,0,0,3600,0,,1,3,1,,150,,2,1,,12,L4OK,,0,\n" +
+	"test,BACKEND,0,0,1,5,200,150,36810,951072,0,0,,0,0,0,0,UP,10,1,0,,0,3600,0,,1,3,0,,150,,1,1,,12,,,,\n" +
+	"test_2,BACKEND,0,0,0,0,200,0,0,0,0,0,,0,0,0,0,DOWN\n\n"
+
+func TestSingleRuntime_GetStats(t *testing.T) {
+	haProxy := NewHAProxyMock(t)
+	haProxy.Start()
+	defer haProxy.Stop()
+
+	haProxy.SetResponses(&map[string]string{"show stat\n": showStat})
+
+	s := &SingleRuntime{}
+	if err := s.Init(haProxy.Addr().String(), 0, 0); err != nil {
+		t.Fatalf("SingleRuntime.Init() error = %v", err)
+	}
+	result := s.GetStats()
+	if result.Error != "" {
+		t.Fatalf("SingleRuntime.GetStats() error = %v", result.Error)
+	}
+	if result.RuntimeAPI != haProxy.Addr().String() {
+		t.Errorf("RuntimeAPI is %v, expected %v", result.RuntimeAPI, haProxy.Addr().String())
+	}
+	if len(result.Stats) != 4 {
+		t.Fatalf("%v stats returned, expected 4", len(result.Stats))
+	}
+
+	for i, want := range []models.NativeStat{
+		{Name: "http", Type: "frontend"},
+		{Name: "WebServ", Type: "server", BackendName: "test"},
+		{Name: "test", Type: "backend"},
+		{Name: "test_2", Type: "backend"},
+	} {
+		got := result.Stats[i]
+		if got.Name != want.Name || got.Type != want.Type || got.BackendName != want.BackendName {
+			t.Errorf("Stat %v is %v %v/%v, expected %v %v/%v", i, got.Type, got.BackendName, got.Name, want.Type, want.BackendName, want.Name)
+		}
+		if got.Stats == nil {
+			t.Fatalf("Stat %v has no stats", i)
+		}
+	}
+
+	frontend := result.Stats[0].Stats
+	if frontend.Stot == nil || *frontend.Stot != 152 {
+		t.Errorf("Frontend stot is %v, expected 152", frontend.Stot)
+	}
+	if frontend.Status != "OPEN" {
+		t.Errorf("Frontend status is %v, expected OPEN", frontend.Status)
+	}
+	if frontend.Qcur != nil {
+		t.Errorf("Frontend qcur is %v, expected empty", *frontend.Qcur)
+	}
+
+	server := result.Stats[1].Stats
+	if server.Weight == nil || *server.Weight != 10 {
+		t.Errorf("Server weight is %v, expected 10", server.Weight)
+	}
+	if server.CheckStatus != "L4OK" {
+		t.Errorf("Server check_status is %v, expected L4OK", server.CheckStatus)
+	}
+
+	short := result.Stats[3].Stats
+	if short.Status != "DOWN" || short.Slim == nil || *short.Slim != 200 {
+		t.Errorf("Short row parsed as %v/%v, expected DOWN/200", short.Status, short.Slim)
+	}
+	if short.Weight != nil {
+		t.Errorf("Short row weight is %v, expected empty", *short.Weight)
+	}
+}
+
+func TestParseStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawdata string
+		want    int
+	}{
+		{name: "Empty output", rawdata: "", want: 0},
+		{name: "Header only", rawdata: "# pxname,svname,type,\n\n", want: 0},
+		{name: "Not a stat output", rawdata: "Unknown command.\n", want: 0},
+		{name: "Without type column", rawdata: "# pxname,svname,scur,\nhttp,FRONTEND,1,\nweb,srv1,0,\nweb,BACKEND,0,\n", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseStats(tt.rawdata); len(got) != tt.want {
+				t.Errorf("ParseStats() returned %v stats, expected %v", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -82,4 +82,18 @@ type IRuntimeClient interface {
 	ParseMapEntries(output string) models.MapEntries
 	// ParseMapEntriesFromFile reads entries from file
 	ParseMapEntriesFromFile(inputFile io.Reader, hasID bool) models.MapEntries
+	// ShowACLS returns structured unique Acl files
+	ShowACLS() (models.ACLFiles, error)
+	// GetACL returns one structured runtime Acl file
+	GetACL(storageName string) (*models.ACLFile, error)
+	// ShowACLFileEntries returns Acl entries by Acl id or file name
+	ShowACLFileEntries(aclID string) (models.ACLFilesEntries, error)
+	// GetACLFileEntry returns one Acl runtime entry
+	GetACLFileEntry(aclID, value string) (*models.ACLFileEntry, error)
+	// AddACLFileEntry adds an entry into the Acl
+	AddACLFileEntry(aclID, value string) error
+	// DeleteACLFileEntry deletes all the Acl entries matching the value
+	DeleteACLFileEntry(aclID, value string) error
+	// ClearACL removes all entries from the Acl
+	ClearACL(aclID string) error
 }
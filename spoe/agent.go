@@ -300,7 +300,7 @@ func (c *SingleSpoe) CreateAgent(scope string, data *models.SpoeAgent, transacti
 	if c.Transaction.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return conf.NewConfError(conf.ErrValidationError, validationErr.Error())
+			return conf.NewValidationError(validationErr)
 		}
 	}
 
@@ -336,7 +336,7 @@ func (c *SingleSpoe) EditAgent(scope string, data *models.SpoeAgent, transaction
 	if c.Transaction.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return conf.NewConfError(conf.ErrValidationError, validationErr.Error())
+			return conf.NewValidationError(validationErr)
 		}
 	}
 
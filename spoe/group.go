@@ -99,7 +99,7 @@ func (c *SingleSpoe) CreateGroup(scope string, data *models.SpoeGroup, transacti
 	if c.Transaction.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return conf.NewConfError(conf.ErrValidationError, validationErr.Error())
+			return conf.NewValidationError(validationErr)
 		}
 	}
 
@@ -136,7 +136,7 @@ func (c *SingleSpoe) EditGroup(scope string, data *models.SpoeGroup, name, trans
 	if c.Transaction.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return conf.NewConfError(conf.ErrValidationError, validationErr.Error())
+			return conf.NewValidationError(validationErr)
 		}
 	}
 
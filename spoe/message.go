@@ -131,7 +131,7 @@ func (c *SingleSpoe) CreateMessage(scope string, data *models.SpoeMessage, trans
 	if c.Transaction.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return conf.NewConfError(conf.ErrValidationError, validationErr.Error())
+			return conf.NewValidationError(validationErr)
 		}
 	}
 
@@ -167,7 +167,7 @@ func (c *SingleSpoe) EditMessage(scope string, data *models.SpoeMessage, name, t
 	if c.Transaction.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return conf.NewConfError(conf.ErrValidationError, validationErr.Error())
+			return conf.NewValidationError(validationErr)
 		}
 	}
 
@@ -96,7 +96,7 @@ func (c *SingleSpoe) CreateScope(data *models.SpoeScope, transactionID string, v
 	if c.Transaction.UseValidation {
 		validationErr := data.Validate(strfmt.Default)
 		if validationErr != nil {
-			return conf.NewConfError(conf.ErrValidationError, validationErr.Error())
+			return conf.NewValidationError(validationErr)
 		}
 	}
 